@@ -54,15 +54,21 @@ func main() {
 	expiry := time.Now().Add(30 * 24 * time.Hour)
 	opt := instrument.NewEuropeanOption("OPT1", underlying, strike, expiry, instrument.Call)
 
+	// Snapshot the underlying's spot from the market data client
+	snapshot, err := market.LoadSnapshot(ctx, client, underlying.Symbol())
+	if err != nil {
+		logger.Fatal("Failed to load market snapshot", zap.Error(err))
+	}
+
 	// Create a pricer with context cancellation support
-	pricer := pricing.NewMonteCarloPricer(500000, 0.05, 0.20)
+	pricer := pricing.NewMonteCarloPricer(snapshot, 500000, 0.05, 0.20)
 
 	// Demonstrate cancellation
 	shortCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
 	defer cancel()
 
 	logger.Info("Running simulation with short timeout (expect cancellation)")
-	_, err := pricer.Price(shortCtx, opt)
+	_, err = pricer.Price(shortCtx, opt)
 	if err != nil {
 		logger.Info("Simulation cancelled as expected", zap.Error(err))
 	}
@@ -75,7 +81,8 @@ func main() {
 		logger.Error("Simulation failed", zap.Error(err))
 	} else {
 		logger.Info("Simulation completed",
-			zap.Float64("value", val),
+			zap.String("value", val.Price.String()),
+			zap.Float64("std_err", val.StdErr),
 			zap.Duration("duration", time.Since(start)),
 		)
 	}
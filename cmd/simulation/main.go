@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
 	"github.com/antigravity/go-finance-sdk/pkg/pricing"
 	"github.com/antigravity/go-finance-sdk/pkg/risk"
 	"github.com/shopspring/decimal"
@@ -23,15 +24,18 @@ func main() {
 
 	fmt.Printf("Instrument: %s (%s) Strike: %s\n", callOption.ID(), callOption.OptionType(), callOption.Strike())
 
+	// Market snapshot: AAPL spot at 100
+	snapshot := market.NewSnapshot(time.Now()).SetSpot(underlying.Symbol(), 100.0)
+
 	// 2. Price using Black-Scholes
-	bsPricer := pricing.NewBlackScholesPricer(0.05, 0.2) // r=5%, sigma=20%
-	bsPrice, _ := bsPricer.Price(callOption)
-	fmt.Printf("Black-Scholes Price: %.4f\n", bsPrice)
+	bsPricer := pricing.NewBlackScholesPricer(snapshot, 0.05, 0.2) // r=5%, sigma=20%
+	bsPrice, _ := bsPricer.Price(context.Background(), callOption)
+	fmt.Printf("Black-Scholes Price: %.4f\n", bsPrice.Value())
 
 	// 3. Price using Monte Carlo (Concurrent)
-	mcPricer := pricing.NewMonteCarloPricer(100000, 0.05, 0.2)
+	mcPricer := pricing.NewMonteCarloPricer(snapshot, 100000, 0.05, 0.2)
 	mcPrice, _ := mcPricer.Price(context.Background(), callOption)
-	fmt.Printf("Monte Carlo Price: %.4f (100k simulations)\n", mcPrice)
+	fmt.Printf("Monte Carlo Price: %.4f ± %.4f (100k simulations)\n", mcPrice.Value(), mcPrice.StdErr)
 
 	// 4. Calculate Risk (VaR)
 	// Mock historical returns
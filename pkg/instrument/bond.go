@@ -0,0 +1,221 @@
+package instrument
+
+import (
+    "time"
+
+    "github.com/shopspring/decimal"
+)
+
+// CouponType says how a bond's coupons are set.
+type CouponType string
+
+const (
+    // FixedCoupon pays a fixed annual rate on the face value.
+    FixedCoupon CouponType = "FIXED"
+    // ZeroCoupon pays only the face value at maturity.
+    ZeroCoupon CouponType = "ZERO"
+    // FloatingCoupon pays a reference rate, fixed at the start of each
+    // period, plus a spread.
+    FloatingCoupon CouponType = "FLOATING"
+)
+
+// DayCount is the convention measuring the accrual of a coupon period.
+type DayCount string
+
+const (
+    // Thirty360 counts 30-day months in a 360-day year (bond basis).
+    Thirty360 DayCount = "30/360"
+    // Actual360 counts actual days in a 360-day year.
+    Actual360 DayCount = "ACT/360"
+    // Actual365Fixed counts actual days in a 365-day year.
+    Actual365Fixed DayCount = "ACT/365F"
+    // ActualActualICMA pays each regular period 1/frequency of a year and
+    // accrues it in proportion to the actual days elapsed.
+    ActualActualICMA DayCount = "ACT/ACT ICMA"
+)
+
+// CouponPeriod is an accrual period of a bond, whose coupon is paid on End.
+type CouponPeriod struct {
+    Start time.Time
+    End   time.Time
+}
+
+// Bond is a fixed-rate, zero-coupon or floating-rate bond repaying its
+// face value at maturity. Coupon periods run back from maturity in steps
+// of 12/frequency months, so an irregular period falls first.
+type Bond struct {
+    id         string
+    currency   string
+    face       decimal.Decimal
+    couponType CouponType
+    coupon     decimal.Decimal
+    frequency  int
+    dayCount   DayCount
+    index      string
+    issue      time.Time
+    maturity   time.Time
+}
+
+// NewFixedRateBond creates a bond paying the annual coupon rate on face,
+// frequency times a year.
+func NewFixedRateBond(id, currency string, face, coupon decimal.Decimal, frequency int, dayCount DayCount, issue, maturity time.Time) *Bond {
+    return &Bond{
+        id:         id,
+        currency:   currency,
+        face:       face,
+        couponType: FixedCoupon,
+        coupon:     coupon,
+        frequency:  frequency,
+        dayCount:   dayCount,
+        issue:      issue,
+        maturity:   maturity,
+    }
+}
+
+// NewZeroCouponBond creates a bond paying face at maturity only.
+func NewZeroCouponBond(id, currency string, face decimal.Decimal, issue, maturity time.Time) *Bond {
+    return &Bond{
+        id:         id,
+        currency:   currency,
+        face:       face,
+        couponType: ZeroCoupon,
+        dayCount:   Actual365Fixed,
+        issue:      issue,
+        maturity:   maturity,
+    }
+}
+
+// NewFloatingRateBond creates a bond paying the rate quoted under the
+// index symbol plus spread on face, frequency times a year.
+func NewFloatingRateBond(id, currency string, face decimal.Decimal, index string, spread decimal.Decimal, frequency int, dayCount DayCount, issue, maturity time.Time) *Bond {
+    return &Bond{
+        id:         id,
+        currency:   currency,
+        face:       face,
+        couponType: FloatingCoupon,
+        coupon:     spread,
+        frequency:  frequency,
+        dayCount:   dayCount,
+        index:      index,
+        issue:      issue,
+        maturity:   maturity,
+    }
+}
+
+func (b *Bond) ID() string {
+    return b.id
+}
+
+func (b *Bond) Type() InstrumentType {
+    return TypeBond
+}
+
+func (b *Bond) Currency() string {
+    return b.currency
+}
+
+// Face returns the amount repaid at maturity, on which coupons accrue.
+func (b *Bond) Face() decimal.Decimal {
+    return b.face
+}
+
+func (b *Bond) CouponType() CouponType {
+    return b.couponType
+}
+
+// Coupon returns the annual coupon rate of a fixed-rate bond, or the
+// spread over the index of a floating-rate one.
+func (b *Bond) Coupon() decimal.Decimal {
+    return b.coupon
+}
+
+// Frequency returns the number of coupons a year; zero for zero-coupon
+// bonds.
+func (b *Bond) Frequency() int {
+    return b.frequency
+}
+
+func (b *Bond) DayCount() DayCount {
+    return b.dayCount
+}
+
+// Index returns the symbol of the reference rate of a floating-rate bond.
+func (b *Bond) Index() string {
+    return b.index
+}
+
+func (b *Bond) Issue() time.Time {
+    return b.issue
+}
+
+func (b *Bond) Maturity() time.Time {
+    return b.maturity
+}
+
+// Schedule returns the coupon periods in order, the first starting on the
+// issue date. Zero-coupon bonds, and bonds whose frequency does not divide
+// a year into whole months, have none.
+func (b *Bond) Schedule() []CouponPeriod {
+    if b.couponType == ZeroCoupon || b.frequency <= 0 || 12%b.frequency != 0 || !b.maturity.After(b.issue) {
+        return nil
+    }
+    months := 12 / b.frequency
+    var ends []time.Time
+    for k := 0; ; k++ {
+        end := addMonths(b.maturity, -months*k)
+        if !end.After(b.issue) {
+            break
+        }
+        ends = append(ends, end)
+    }
+
+    periods := make([]CouponPeriod, len(ends))
+    start := b.issue
+    for i := range periods {
+        end := ends[len(ends)-1-i]
+        periods[i] = CouponPeriod{Start: start, End: end}
+        start = end
+    }
+    return periods
+}
+
+// Accrual returns the year fraction accrued over period p up to t, which
+// lies within it, under the bond's day count.
+func (b *Bond) Accrual(p CouponPeriod, t time.Time) float64 {
+    days := t.Sub(p.Start).Hours() / 24
+    switch b.dayCount {
+    case Thirty360:
+        return thirty360(p.Start, t) / 360
+    case Actual360:
+        return days / 360
+    case ActualActualICMA:
+        // Measured against the regular period ending with p
+        regular := p.End.Sub(addMonths(p.End, -12/b.frequency)).Hours() / 24
+        return days / regular / float64(b.frequency)
+    default:
+        return days / 365
+    }
+}
+
+// thirty360 returns the days from start to end in 30-day months, with the
+// 31st counted as the 30th (bond basis).
+func thirty360(start, end time.Time) float64 {
+    y1, m1, d1 := start.Date()
+    y2, m2, d2 := end.Date()
+    if d1 == 31 {
+        d1 = 30
+    }
+    if d2 == 31 && d1 == 30 {
+        d2 = 30
+    }
+    return float64(360*(y2-y1) + 30*(int(m2)-int(m1)) + d2 - d1)
+}
+
+// addMonths moves t by n months, keeping its day of the month where the
+// target month has it and taking the month's last day otherwise.
+func addMonths(t time.Time, n int) time.Time {
+    y, m, d := t.Date()
+    first := time.Date(y, m+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+    last := first.AddDate(0, 1, -1).Day()
+    return first.AddDate(0, 0, min(d, last)-1)
+}
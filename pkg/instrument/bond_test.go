@@ -0,0 +1,71 @@
+package instrument
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBond(t *testing.T) {
+	issue, maturity := date(2025, 1, 15), date(2030, 1, 15)
+	fixed := NewFixedRateBond("T-5", "USD", decimal.NewFromInt(100), decimal.NewFromFloat(0.045), 2, Thirty360, issue, maturity)
+	assert.Equal(t, "T-5", fixed.ID())
+	assert.Equal(t, "USD", fixed.Currency())
+	assert.Equal(t, TypeBond, fixed.Type())
+	assert.Equal(t, FixedCoupon, fixed.CouponType())
+	assert.True(t, decimal.NewFromFloat(0.045).Equal(fixed.Coupon()))
+	assert.Equal(t, maturity, fixed.Maturity())
+	assert.Len(t, fixed.Schedule(), 10)
+
+	zero := NewZeroCouponBond("Z-5", "USD", decimal.NewFromInt(100), issue, maturity)
+	assert.Equal(t, ZeroCoupon, zero.CouponType())
+	assert.Empty(t, zero.Schedule())
+
+	frn := NewFloatingRateBond("F-5", "EUR", decimal.NewFromInt(100), "EUR3M", decimal.NewFromFloat(0.01), 4, Actual360, issue, maturity)
+	assert.Equal(t, FloatingCoupon, frn.CouponType())
+	assert.Equal(t, "EUR3M", frn.Index())
+	assert.Len(t, frn.Schedule(), 20)
+}
+
+func TestBond_Schedule(t *testing.T) {
+	// Periods run back from maturity, leaving a short first period, and a
+	// maturity at the end of a month keeps coupons there
+	b := NewFixedRateBond("B", "USD", decimal.NewFromInt(100), decimal.NewFromFloat(0.05), 4, Actual365Fixed, date(2025, 2, 10), date(2026, 2, 28))
+	assert.Equal(t, []CouponPeriod{
+		{Start: date(2025, 2, 10), End: date(2025, 2, 28)},
+		{Start: date(2025, 2, 28), End: date(2025, 5, 28)},
+		{Start: date(2025, 5, 28), End: date(2025, 8, 28)},
+		{Start: date(2025, 8, 28), End: date(2025, 11, 28)},
+		{Start: date(2025, 11, 28), End: date(2026, 2, 28)},
+	}, b.Schedule())
+
+	b = NewFixedRateBond("B", "USD", decimal.NewFromInt(100), decimal.NewFromFloat(0.05), 2, Actual365Fixed, date(2025, 3, 1), date(2026, 8, 31))
+	s := b.Schedule()
+	assert.Equal(t, date(2025, 8, 31), s[0].End)
+	assert.Equal(t, date(2026, 2, 28), s[1].End)
+
+	// A frequency that does not divide the year has no schedule
+	b = NewFixedRateBond("B", "USD", decimal.NewFromInt(100), decimal.NewFromFloat(0.05), 5, Actual365Fixed, date(2025, 1, 1), date(2026, 1, 1))
+	assert.Empty(t, b.Schedule())
+}
+
+func TestBond_Accrual(t *testing.T) {
+	p := CouponPeriod{Start: date(2025, 1, 31), End: date(2025, 7, 31)}
+	mid := date(2025, 4, 30)
+	bond := func(dc DayCount) *Bond {
+		return NewFixedRateBond("B", "USD", decimal.NewFromInt(100), decimal.NewFromFloat(0.05), 2, dc, date(2025, 1, 31), date(2030, 1, 31))
+	}
+
+	assert.InDelta(t, 90.0/360, bond(Thirty360).Accrual(p, mid), 1e-12)
+	assert.InDelta(t, 0.5, bond(Thirty360).Accrual(p, p.End), 1e-12)
+	assert.InDelta(t, 89.0/360, bond(Actual360).Accrual(p, mid), 1e-12)
+	assert.InDelta(t, 89.0/365, bond(Actual365Fixed).Accrual(p, mid), 1e-12)
+	assert.InDelta(t, 0.5, bond(ActualActualICMA).Accrual(p, p.End), 1e-12)
+	assert.InDelta(t, 89.0/181/2, bond(ActualActualICMA).Accrual(p, mid), 1e-12)
+}
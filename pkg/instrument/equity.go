@@ -1,10 +1,47 @@
 package instrument
 
+import (
+    "sort"
+    "time"
+
+    "github.com/shopspring/decimal"
+)
+
+// DividendKind says how a discrete dividend is paid.
+type DividendKind string
+
+const (
+    // CashDividend pays a fixed amount per share.
+    CashDividend DividendKind = "CASH"
+    // ProportionalDividend pays a fraction of the share price.
+    ProportionalDividend DividendKind = "PROPORTIONAL"
+)
+
+// Dividend is a discrete dividend going ex on ExDate. Amount is per share
+// for cash dividends and a fraction of the spot, such as 0.02, for
+// proportional ones.
+type Dividend struct {
+    ExDate time.Time
+    Amount decimal.Decimal
+    Kind   DividendKind
+}
+
+// NewCashDividend creates a dividend paying amount per share.
+func NewCashDividend(exDate time.Time, amount decimal.Decimal) Dividend {
+    return Dividend{ExDate: exDate, Amount: amount, Kind: CashDividend}
+}
+
+// NewProportionalDividend creates a dividend paying fraction of the spot.
+func NewProportionalDividend(exDate time.Time, fraction decimal.Decimal) Dividend {
+    return Dividend{ExDate: exDate, Amount: fraction, Kind: ProportionalDividend}
+}
+
 // Equity represents a stock or share.
 type Equity struct {
-    id       string
-    currency string
-    symbol   string
+    id        string
+    currency  string
+    symbol    string
+    dividends []Dividend
 }
 
 // NewEquity creates a new Equity instrument.
@@ -16,6 +53,17 @@ func NewEquity(id, currency, symbol string) *Equity {
     }
 }
 
+// WithDividends returns a copy of the equity paying the given discrete
+// dividends, in addition to any continuous yield quoted by the market.
+func (e *Equity) WithDividends(divs ...Dividend) *Equity {
+    schedule := append([]Dividend(nil), divs...)
+    sort.SliceStable(schedule, func(i, j int) bool { return schedule[i].ExDate.Before(schedule[j].ExDate) })
+
+    c := *e
+    c.dividends = schedule
+    return &c
+}
+
 func (e *Equity) ID() string {
     return e.id
 }
@@ -31,3 +79,8 @@ func (e *Equity) Currency() string {
 func (e *Equity) Symbol() string {
     return e.symbol
 }
+
+// Dividends returns the discrete dividend schedule in ex-date order.
+func (e *Equity) Dividends() []Dividend {
+    return append([]Dividend(nil), e.dividends...)
+}
@@ -2,7 +2,9 @@ package instrument
 
 import (
 	"testing"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,3 +16,30 @@ func TestEquity(t *testing.T) {
 	assert.Equal(t, "AAPL", eq.Symbol())
 	assert.Equal(t, TypeEquity, eq.Type())
 }
+
+func TestEquityDividends(t *testing.T) {
+	eq := NewEquity("AAPL-US", "USD", "AAPL")
+	assert.Empty(t, eq.Dividends())
+
+	may := time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 7, 0, 0, 0, 0, time.UTC)
+	paying := eq.WithDividends(
+		NewCashDividend(may, decimal.NewFromFloat(0.25)),
+		NewProportionalDividend(feb, decimal.NewFromFloat(0.01)),
+	)
+
+	assert.Empty(t, eq.Dividends(), "the original is unchanged")
+	assert.Equal(t, "AAPL", paying.Symbol())
+
+	divs := paying.Dividends()
+	if assert.Len(t, divs, 2) {
+		assert.Equal(t, feb, divs[0].ExDate)
+		assert.Equal(t, ProportionalDividend, divs[0].Kind)
+		assert.Equal(t, may, divs[1].ExDate)
+		assert.Equal(t, CashDividend, divs[1].Kind)
+		assert.True(t, divs[1].Amount.Equal(decimal.NewFromFloat(0.25)))
+	}
+
+	divs[0].Kind = CashDividend
+	assert.Equal(t, ProportionalDividend, paying.Dividends()[0].Kind, "the schedule is copied")
+}
@@ -0,0 +1,175 @@
+package instrument
+
+import (
+    "sort"
+    "time"
+
+    "github.com/shopspring/decimal"
+)
+
+// BarrierType says on which side of the spot the barrier lies and whether
+// touching it activates (in) or extinguishes (out) the option.
+type BarrierType string
+
+const (
+    UpAndOut   BarrierType = "UP_AND_OUT"
+    UpAndIn    BarrierType = "UP_AND_IN"
+    DownAndOut BarrierType = "DOWN_AND_OUT"
+    DownAndIn  BarrierType = "DOWN_AND_IN"
+)
+
+// Up reports whether the barrier lies above the spot.
+func (b BarrierType) Up() bool {
+    return b == UpAndOut || b == UpAndIn
+}
+
+// In reports whether touching the barrier activates the option.
+func (b BarrierType) In() bool {
+    return b == UpAndIn || b == DownAndIn
+}
+
+// BarrierOption is a European option that is knocked in or out when the
+// underlying touches the barrier before expiry. The barrier is monitored
+// continuously. A knocked-out option pays the rebate when the barrier is
+// hit; a knock-in option that never knocks in pays it at expiry.
+type BarrierOption struct {
+    *Option
+    barrierType BarrierType
+    barrier     decimal.Decimal
+    rebate      decimal.Decimal
+}
+
+// NewBarrierOption creates a new barrier option.
+func NewBarrierOption(id string, underlying Instrument, strike decimal.Decimal, expiry time.Time, optType OptionType, barrierType BarrierType, barrier, rebate decimal.Decimal) *BarrierOption {
+    return &BarrierOption{
+        Option:      NewEuropeanOption(id, underlying, strike, expiry, optType),
+        barrierType: barrierType,
+        barrier:     barrier,
+        rebate:      rebate,
+    }
+}
+
+func (b *BarrierOption) BarrierType() BarrierType {
+    return b.barrierType
+}
+
+func (b *BarrierOption) Barrier() decimal.Decimal {
+    return b.barrier
+}
+
+func (b *BarrierOption) Rebate() decimal.Decimal {
+    return b.rebate
+}
+
+// Averaging selects how an Asian option averages its fixings.
+type Averaging string
+
+const (
+    ArithmeticAverage Averaging = "ARITHMETIC"
+    GeometricAverage  Averaging = "GEOMETRIC"
+)
+
+// AsianOption is a European option on the average of the underlying over a
+// schedule of fixing dates. The latest fixing date is the expiry.
+type AsianOption struct {
+    *Option
+    averaging Averaging
+    fixings   []time.Time
+}
+
+// NewAsianOption creates a new fixed-strike Asian option.
+func NewAsianOption(id string, underlying Instrument, strike decimal.Decimal, fixingDates []time.Time, optType OptionType, averaging Averaging) *AsianOption {
+    dates := append([]time.Time(nil), fixingDates...)
+    sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+    var expiry time.Time
+    if len(dates) > 0 {
+        expiry = dates[len(dates)-1]
+    }
+    return &AsianOption{
+        Option:    NewEuropeanOption(id, underlying, strike, expiry, optType),
+        averaging: averaging,
+        fixings:   dates,
+    }
+}
+
+func (a *AsianOption) Averaging() Averaging {
+    return a.averaging
+}
+
+// Fixings returns the fixing dates in ascending order.
+func (a *AsianOption) Fixings() []time.Time {
+    return append([]time.Time(nil), a.fixings...)
+}
+
+// LookbackStrike selects the payoff of a lookback option.
+type LookbackStrike string
+
+const (
+    // FixedStrike pays max(Smax-K, 0) for calls and max(K-Smin, 0) for puts.
+    FixedStrike LookbackStrike = "FIXED"
+    // FloatingStrike pays S_T-Smin for calls and Smax-S_T for puts.
+    FloatingStrike LookbackStrike = "FLOATING"
+)
+
+// LookbackOption is a European option on the maximum or minimum of the
+// underlying, monitored continuously from inception to expiry.
+type LookbackOption struct {
+    *Option
+    strikeType LookbackStrike
+}
+
+// NewLookbackOption creates a new lookback option. The strike is ignored
+// for floating-strike lookbacks.
+func NewLookbackOption(id string, underlying Instrument, strike decimal.Decimal, expiry time.Time, optType OptionType, strikeType LookbackStrike) *LookbackOption {
+    return &LookbackOption{
+        Option:     NewEuropeanOption(id, underlying, strike, expiry, optType),
+        strikeType: strikeType,
+    }
+}
+
+func (l *LookbackOption) StrikeType() LookbackStrike {
+    return l.strikeType
+}
+
+// DigitalPayout selects what a digital option pays when it expires in the money.
+type DigitalPayout string
+
+const (
+    CashOrNothing  DigitalPayout = "CASH_OR_NOTHING"
+    AssetOrNothing DigitalPayout = "ASSET_OR_NOTHING"
+)
+
+// DigitalOption is a European option paying a fixed cash amount or one unit
+// of the underlying if it expires in the money, and nothing otherwise.
+type DigitalOption struct {
+    *Option
+    payout DigitalPayout
+    cash   decimal.Decimal
+}
+
+// NewCashOrNothingOption creates a digital option paying cash in the money.
+func NewCashOrNothingOption(id string, underlying Instrument, strike decimal.Decimal, expiry time.Time, optType OptionType, cash decimal.Decimal) *DigitalOption {
+    return &DigitalOption{
+        Option: NewEuropeanOption(id, underlying, strike, expiry, optType),
+        payout: CashOrNothing,
+        cash:   cash,
+    }
+}
+
+// NewAssetOrNothingOption creates a digital option paying the underlying in the money.
+func NewAssetOrNothingOption(id string, underlying Instrument, strike decimal.Decimal, expiry time.Time, optType OptionType) *DigitalOption {
+    return &DigitalOption{
+        Option: NewEuropeanOption(id, underlying, strike, expiry, optType),
+        payout: AssetOrNothing,
+    }
+}
+
+func (d *DigitalOption) Payout() DigitalPayout {
+    return d.payout
+}
+
+// Cash returns the amount paid by a cash-or-nothing option.
+func (d *DigitalOption) Cash() decimal.Decimal {
+    return d.cash
+}
@@ -0,0 +1,42 @@
+package instrument
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExoticOptions(t *testing.T) {
+	underlying := NewEquity("AAPL-US", "USD", "AAPL")
+	strike := decimal.NewFromInt(150)
+	now := time.Now()
+	expiry := now.AddDate(1, 0, 0)
+
+	barrier := NewBarrierOption("B", underlying, strike, expiry, Call, UpAndOut, decimal.NewFromInt(180), decimal.NewFromInt(2))
+	assert.Equal(t, TypeOption, barrier.Type())
+	assert.Equal(t, "USD", barrier.Currency())
+	assert.Equal(t, European, barrier.Style())
+	assert.Equal(t, expiry, barrier.Expiry())
+	assert.True(t, barrier.BarrierType().Up())
+	assert.False(t, barrier.BarrierType().In())
+	assert.True(t, DownAndIn.In())
+	assert.Equal(t, decimal.NewFromInt(180), barrier.Barrier())
+	assert.Equal(t, decimal.NewFromInt(2), barrier.Rebate())
+
+	fixings := []time.Time{now.AddDate(0, 6, 0), now.AddDate(0, 3, 0), now.AddDate(1, 0, 0)}
+	asian := NewAsianOption("A", underlying, strike, fixings, Put, ArithmeticAverage)
+	assert.Equal(t, fixings[2], asian.Expiry())
+	assert.Equal(t, []time.Time{fixings[1], fixings[0], fixings[2]}, asian.Fixings())
+	assert.Equal(t, ArithmeticAverage, asian.Averaging())
+
+	lookback := NewLookbackOption("L", underlying, decimal.Zero, expiry, Call, FloatingStrike)
+	assert.Equal(t, FloatingStrike, lookback.StrikeType())
+
+	cash := NewCashOrNothingOption("C", underlying, strike, expiry, Put, decimal.NewFromInt(10))
+	assert.Equal(t, CashOrNothing, cash.Payout())
+	assert.Equal(t, decimal.NewFromInt(10), cash.Cash())
+	asset := NewAssetOrNothingOption("D", underlying, strike, expiry, Call)
+	assert.Equal(t, AssetOrNothing, asset.Payout())
+}
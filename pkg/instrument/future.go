@@ -0,0 +1,44 @@
+package instrument
+
+import "time"
+
+// Future is an exchange-traded futures contract, or any other forward
+// quantity quoted under a symbol, such as a forward rate. Its market quote
+// is the futures price or forward level, which may be negative for rates.
+type Future struct {
+    id       string
+    currency string
+    symbol   string
+    expiry   time.Time
+}
+
+// NewFuture creates a new futures contract settling on expiry.
+func NewFuture(id, currency, symbol string, expiry time.Time) *Future {
+    return &Future{
+        id:       id,
+        currency: currency,
+        symbol:   symbol,
+        expiry:   expiry,
+    }
+}
+
+func (f *Future) ID() string {
+    return f.id
+}
+
+func (f *Future) Type() InstrumentType {
+    return TypeFuture
+}
+
+func (f *Future) Currency() string {
+    return f.currency
+}
+
+func (f *Future) Symbol() string {
+    return f.symbol
+}
+
+// Expiry returns the last trading or settlement date of the contract.
+func (f *Future) Expiry() time.Time {
+    return f.expiry
+}
@@ -0,0 +1,24 @@
+package instrument
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuture(t *testing.T) {
+	expiry := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+	fut := NewFuture("CLZ5", "USD", "CL", expiry)
+
+	assert.Equal(t, "CLZ5", fut.ID())
+	assert.Equal(t, "USD", fut.Currency())
+	assert.Equal(t, "CL", fut.Symbol())
+	assert.Equal(t, expiry, fut.Expiry())
+	assert.Equal(t, TypeFuture, fut.Type())
+
+	opt := NewEuropeanOption("CLZ5-C-70", fut, decimal.NewFromInt(70), expiry.AddDate(0, -1, 0), Call)
+	assert.Equal(t, fut, opt.Underlying())
+	assert.Equal(t, "USD", opt.Currency())
+}
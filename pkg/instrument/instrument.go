@@ -7,6 +7,7 @@ const (
     TypeEquity InstrumentType = "EQUITY"
     TypeBond   InstrumentType = "BOND"
     TypeOption InstrumentType = "OPTION"
+    TypeFuture InstrumentType = "FUTURE"
 )
 
 // Instrument represents a tradeable financial asset.
@@ -0,0 +1,126 @@
+package instrument
+
+import (
+    "time"
+
+    "github.com/shopspring/decimal"
+)
+
+// MultiAssetPayout says how a multi-asset option combines the weighted
+// spots of its underlyings at expiry into the value compared to the strike.
+type MultiAssetPayout string
+
+const (
+    // BasketPayout sums the weighted spots.
+    BasketPayout MultiAssetPayout = "BASKET"
+    // BestOfPayout takes the highest weighted spot.
+    BestOfPayout MultiAssetPayout = "BEST_OF"
+    // WorstOfPayout takes the lowest weighted spot.
+    WorstOfPayout MultiAssetPayout = "WORST_OF"
+    // SpreadPayout subtracts the second weighted spot from the first.
+    SpreadPayout MultiAssetPayout = "SPREAD"
+)
+
+// MultiAssetOption is a European option on a combination of the spots of
+// several underlyings at expiry. A call pays the combination less the
+// strike when positive, a put the strike less the combination. All
+// underlyings are expected to share a currency.
+type MultiAssetOption struct {
+    id          string
+    underlyings []Instrument
+    weights     []decimal.Decimal
+    strike      decimal.Decimal
+    expiry      time.Time
+    optionType  OptionType
+    payout      MultiAssetPayout
+}
+
+// NewBasketOption creates a new option on the weighted sum of the
+// underlyings' spots. weights[i] applies to underlyings[i].
+func NewBasketOption(id string, underlyings []Instrument, weights []decimal.Decimal, strike decimal.Decimal, expiry time.Time, optType OptionType) *MultiAssetOption {
+    return newMultiAssetOption(id, underlyings, weights, strike, expiry, optType, BasketPayout)
+}
+
+// NewBestOfOption creates a new option on the highest of the underlyings'
+// spots. Use NewRainbowOption to compare performances instead of prices.
+func NewBestOfOption(id string, underlyings []Instrument, strike decimal.Decimal, expiry time.Time, optType OptionType) *MultiAssetOption {
+    return newMultiAssetOption(id, underlyings, unitWeights(len(underlyings)), strike, expiry, optType, BestOfPayout)
+}
+
+// NewWorstOfOption creates a new option on the lowest of the underlyings'
+// spots.
+func NewWorstOfOption(id string, underlyings []Instrument, strike decimal.Decimal, expiry time.Time, optType OptionType) *MultiAssetOption {
+    return newMultiAssetOption(id, underlyings, unitWeights(len(underlyings)), strike, expiry, optType, WorstOfPayout)
+}
+
+// NewRainbowOption creates a new best-of or worst-of option on the weighted
+// spots; weights of one over the initial spots compare performances.
+func NewRainbowOption(id string, underlyings []Instrument, weights []decimal.Decimal, strike decimal.Decimal, expiry time.Time, optType OptionType, payout MultiAssetPayout) *MultiAssetOption {
+    return newMultiAssetOption(id, underlyings, weights, strike, expiry, optType, payout)
+}
+
+// NewSpreadOption creates a new option on the spot of long less the spot
+// of short. A zero strike gives an exchange option.
+func NewSpreadOption(id string, long, short Instrument, strike decimal.Decimal, expiry time.Time, optType OptionType) *MultiAssetOption {
+    return newMultiAssetOption(id, []Instrument{long, short}, unitWeights(2), strike, expiry, optType, SpreadPayout)
+}
+
+func newMultiAssetOption(id string, underlyings []Instrument, weights []decimal.Decimal, strike decimal.Decimal, expiry time.Time, optType OptionType, payout MultiAssetPayout) *MultiAssetOption {
+    return &MultiAssetOption{
+        id:          id,
+        underlyings: append([]Instrument(nil), underlyings...),
+        weights:     append([]decimal.Decimal(nil), weights...),
+        strike:      strike,
+        expiry:      expiry,
+        optionType:  optType,
+        payout:      payout,
+    }
+}
+
+func unitWeights(n int) []decimal.Decimal {
+    w := make([]decimal.Decimal, n)
+    for i := range w {
+        w[i] = decimal.NewFromInt(1)
+    }
+    return w
+}
+
+func (m *MultiAssetOption) ID() string {
+    return m.id
+}
+
+func (m *MultiAssetOption) Type() InstrumentType {
+    return TypeOption
+}
+
+// Currency returns the currency of the first underlying.
+func (m *MultiAssetOption) Currency() string {
+    if len(m.underlyings) == 0 {
+        return ""
+    }
+    return m.underlyings[0].Currency()
+}
+
+func (m *MultiAssetOption) Underlyings() []Instrument {
+    return append([]Instrument(nil), m.underlyings...)
+}
+
+func (m *MultiAssetOption) Weights() []decimal.Decimal {
+    return append([]decimal.Decimal(nil), m.weights...)
+}
+
+func (m *MultiAssetOption) Strike() decimal.Decimal {
+    return m.strike
+}
+
+func (m *MultiAssetOption) Expiry() time.Time {
+    return m.expiry
+}
+
+func (m *MultiAssetOption) OptionType() OptionType {
+    return m.optionType
+}
+
+func (m *MultiAssetOption) Payout() MultiAssetPayout {
+    return m.payout
+}
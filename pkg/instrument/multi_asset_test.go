@@ -0,0 +1,45 @@
+package instrument
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiAssetOptions(t *testing.T) {
+	aapl := NewEquity("AAPL-US", "USD", "AAPL")
+	msft := NewEquity("MSFT-US", "USD", "MSFT")
+	strike := decimal.NewFromInt(100)
+	expiry := time.Now().AddDate(1, 0, 0)
+	weights := []decimal.Decimal{decimal.NewFromFloat(0.6), decimal.NewFromFloat(0.4)}
+
+	basket := NewBasketOption("B", []Instrument{aapl, msft}, weights, strike, expiry, Call)
+	assert.Equal(t, TypeOption, basket.Type())
+	assert.Equal(t, "USD", basket.Currency())
+	assert.Equal(t, BasketPayout, basket.Payout())
+	assert.Equal(t, []Instrument{aapl, msft}, basket.Underlyings())
+	assert.Equal(t, weights, basket.Weights())
+	assert.Equal(t, strike, basket.Strike())
+	assert.Equal(t, expiry, basket.Expiry())
+	assert.Equal(t, Call, basket.OptionType())
+
+	// Inputs are copied
+	weights[0] = decimal.Zero
+	assert.Equal(t, decimal.NewFromFloat(0.6), basket.Weights()[0])
+
+	best := NewBestOfOption("BO", []Instrument{aapl, msft}, strike, expiry, Call)
+	assert.Equal(t, BestOfPayout, best.Payout())
+	assert.Equal(t, []decimal.Decimal{decimal.NewFromInt(1), decimal.NewFromInt(1)}, best.Weights())
+	assert.Equal(t, WorstOfPayout, NewWorstOfOption("WO", []Instrument{aapl, msft}, strike, expiry, Put).Payout())
+
+	performance := []decimal.Decimal{decimal.NewFromFloat(0.01), decimal.NewFromFloat(0.005)}
+	rainbow := NewRainbowOption("R", []Instrument{aapl, msft}, performance, decimal.NewFromInt(1), expiry, Call, WorstOfPayout)
+	assert.Equal(t, WorstOfPayout, rainbow.Payout())
+	assert.Equal(t, performance, rainbow.Weights())
+
+	spread := NewSpreadOption("S", aapl, msft, decimal.Zero, expiry, Call)
+	assert.Equal(t, SpreadPayout, spread.Payout())
+	assert.Equal(t, []Instrument{aapl, msft}, spread.Underlyings())
+}
@@ -1,6 +1,7 @@
 package instrument
 
 import (
+    "sort"
     "time"
 
     "github.com/shopspring/decimal"
@@ -18,6 +19,7 @@ type ExerciseStyle string
 const (
     European ExerciseStyle = "EUROPEAN"
     American ExerciseStyle = "AMERICAN"
+    Bermudan ExerciseStyle = "BERMUDAN"
 )
 
 // Option represents a financial option contract.
@@ -28,6 +30,7 @@ type Option struct {
     expiry        time.Time
     optionType    OptionType
     exerciseStyle ExerciseStyle
+    exerciseDates []time.Time
 }
 
 // NewEuropeanOption creates a new European option.
@@ -42,6 +45,39 @@ func NewEuropeanOption(id string, underlying Instrument, strike decimal.Decimal,
     }
 }
 
+// NewAmericanOption creates a new American option, exercisable at any time up to expiry.
+func NewAmericanOption(id string, underlying Instrument, strike decimal.Decimal, expiry time.Time, optType OptionType) *Option {
+    return &Option{
+        id:            id,
+        underlying:    underlying,
+        strike:        strike,
+        expiry:        expiry,
+        optionType:    optType,
+        exerciseStyle: American,
+    }
+}
+
+// NewBermudanOption creates a new Bermudan option, exercisable on the given
+// dates only. The latest exercise date is the expiry.
+func NewBermudanOption(id string, underlying Instrument, strike decimal.Decimal, exerciseDates []time.Time, optType OptionType) *Option {
+    dates := append([]time.Time(nil), exerciseDates...)
+    sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+    var expiry time.Time
+    if len(dates) > 0 {
+        expiry = dates[len(dates)-1]
+    }
+    return &Option{
+        id:            id,
+        underlying:    underlying,
+        strike:        strike,
+        expiry:        expiry,
+        optionType:    optType,
+        exerciseStyle: Bermudan,
+        exerciseDates: dates,
+    }
+}
+
 func (o *Option) ID() string {
     return o.id
 }
@@ -73,3 +109,9 @@ func (o *Option) OptionType() OptionType {
 func (o *Option) Style() ExerciseStyle {
     return o.exerciseStyle
 }
+
+// ExerciseDates returns the exercise schedule of a Bermudan option in
+// ascending order. It is nil for European and American options.
+func (o *Option) ExerciseDates() []time.Time {
+    return append([]time.Time(nil), o.exerciseDates...)
+}
@@ -24,3 +24,29 @@ func TestOption(t *testing.T) {
 	assert.Equal(t, TypeOption, opt.Type())
 	assert.Equal(t, "USD", opt.Currency())
 }
+
+func TestAmericanOption(t *testing.T) {
+	underlying := NewEquity("AAPL-US", "USD", "AAPL")
+	strike := decimal.NewFromInt(150)
+	expiry := time.Now().Add(30 * 24 * time.Hour)
+
+	opt := NewAmericanOption("AAPL-PUT-150", underlying, strike, expiry, Put)
+
+	assert.Equal(t, "AAPL-PUT-150", opt.ID())
+	assert.Equal(t, Put, opt.OptionType())
+	assert.Equal(t, American, opt.Style())
+	assert.Equal(t, TypeOption, opt.Type())
+}
+
+func TestBermudanOption(t *testing.T) {
+	underlying := NewEquity("AAPL-US", "USD", "AAPL")
+	strike := decimal.NewFromInt(150)
+	now := time.Now()
+	dates := []time.Time{now.AddDate(0, 6, 0), now.AddDate(0, 3, 0), now.AddDate(1, 0, 0)}
+
+	opt := NewBermudanOption("AAPL-PUT-150", underlying, strike, dates, Put)
+
+	assert.Equal(t, Bermudan, opt.Style())
+	assert.Equal(t, dates[2], opt.Expiry())
+	assert.Equal(t, []time.Time{dates[1], dates[0], dates[2]}, opt.ExerciseDates())
+}
@@ -0,0 +1,62 @@
+package instrument
+
+import (
+    "sort"
+    "time"
+
+    "github.com/antigravity/go-finance-sdk/pkg/payoff"
+)
+
+// StructuredOption pays an arbitrary function of the underlying's path at
+// expiry. The payoff sees the spot on each observation date; when there
+// are none, pricers observe the path on their own time grid.
+type StructuredOption struct {
+    id           string
+    underlying   Instrument
+    expiry       time.Time
+    observations []time.Time
+    payoff       payoff.Payoff
+}
+
+// NewStructuredOption creates a new option paying p at expiry.
+func NewStructuredOption(id string, underlying Instrument, expiry time.Time, observationDates []time.Time, p payoff.Payoff) *StructuredOption {
+    dates := append([]time.Time(nil), observationDates...)
+    sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+    return &StructuredOption{
+        id:           id,
+        underlying:   underlying,
+        expiry:       expiry,
+        observations: dates,
+        payoff:       p,
+    }
+}
+
+func (s *StructuredOption) ID() string {
+    return s.id
+}
+
+func (s *StructuredOption) Type() InstrumentType {
+    return TypeOption
+}
+
+func (s *StructuredOption) Currency() string {
+    return s.underlying.Currency()
+}
+
+func (s *StructuredOption) Underlying() Instrument {
+    return s.underlying
+}
+
+func (s *StructuredOption) Expiry() time.Time {
+    return s.expiry
+}
+
+// ObservationDates returns the observation schedule in ascending order.
+func (s *StructuredOption) ObservationDates() []time.Time {
+    return append([]time.Time(nil), s.observations...)
+}
+
+func (s *StructuredOption) Payoff() payoff.Payoff {
+    return s.payoff
+}
@@ -0,0 +1,33 @@
+package instrument
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/payoff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructuredOption(t *testing.T) {
+	underlying := NewEquity("AAPL-US", "USD", "AAPL")
+	now := time.Now()
+	expiry := now.Add(365 * 24 * time.Hour)
+	dates := []time.Time{expiry, now.Add(90 * 24 * time.Hour), now.Add(180 * 24 * time.Hour)}
+	p := payoff.Call(payoff.Average(), 100)
+
+	opt := NewStructuredOption("AAPL-ASIAN", underlying, expiry, dates, p)
+
+	assert.Equal(t, "AAPL-ASIAN", opt.ID())
+	assert.Equal(t, TypeOption, opt.Type())
+	assert.Equal(t, "USD", opt.Currency())
+	assert.Equal(t, underlying, opt.Underlying())
+	assert.Equal(t, expiry, opt.Expiry())
+	assert.Equal(t, []time.Time{dates[1], dates[2], dates[0]}, opt.ObservationDates())
+	assert.NotNil(t, opt.Payoff())
+
+	// The schedule is copied in and out
+	dates[0] = now
+	opt.ObservationDates()[0] = now
+	assert.Equal(t, expiry, opt.ObservationDates()[2])
+	assert.NotEqual(t, now, opt.ObservationDates()[0])
+}
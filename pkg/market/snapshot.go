@@ -0,0 +1,214 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Snapshot is a point-in-time view of the market inputs needed to value
+// instruments: spot prices and optional lognormal and normal volatilities,
+// lognormal and normal volatility surfaces and dividend yields per
+// underlying symbol, correlations between pairs of symbols, plus an
+// optional risk-free rate. It is safe for concurrent use.
+type Snapshot struct {
+	asOf time.Time
+
+	mu        sync.RWMutex
+	rate      float64
+	hasRate   bool
+	spots     map[string]float64
+	vols      map[string]float64
+	normVols  map[string]float64
+	surfaces  map[string]VolSurface
+	normSurfs map[string]VolSurface
+	dividends map[string]float64
+	corrs     map[[2]string]float64
+}
+
+// NewSnapshot creates an empty snapshot valued as of the given time.
+// A zero asOf means pricers should value "now".
+func NewSnapshot(asOf time.Time) *Snapshot {
+	return &Snapshot{
+		asOf:      asOf,
+		spots:     make(map[string]float64),
+		vols:      make(map[string]float64),
+		normVols:  make(map[string]float64),
+		surfaces:  make(map[string]VolSurface),
+		normSurfs: make(map[string]VolSurface),
+		dividends: make(map[string]float64),
+		corrs:     make(map[[2]string]float64),
+	}
+}
+
+// LoadSnapshot builds a snapshot by fetching the spot of every symbol from p.
+// The snapshot is stamped with the time of the call.
+func LoadSnapshot(ctx context.Context, p Provider, symbols ...string) (*Snapshot, error) {
+	s := NewSnapshot(time.Now())
+	for _, symbol := range symbols {
+		price, err := p.GetPrice(ctx, symbol)
+		if err != nil {
+			return nil, fmt.Errorf("load spot for %s: %w", symbol, err)
+		}
+		s.SetSpot(symbol, price.Value.InexactFloat64())
+	}
+	return s, nil
+}
+
+// AsOf returns the valuation time of the snapshot.
+func (s *Snapshot) AsOf() time.Time {
+	return s.asOf
+}
+
+// SetSpot records the spot price of symbol.
+func (s *Snapshot) SetSpot(symbol string, spot float64) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spots[symbol] = spot
+	return s
+}
+
+// Spot returns the spot price of symbol and whether it is known.
+func (s *Snapshot) Spot(symbol string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.spots[symbol]
+	return v, ok
+}
+
+// SetRiskFreeRate records the continuously compounded risk-free rate.
+func (s *Snapshot) SetRiskFreeRate(r float64) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rate = r
+	s.hasRate = true
+	return s
+}
+
+// RiskFreeRate returns the risk-free rate and whether one was set.
+func (s *Snapshot) RiskFreeRate() (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rate, s.hasRate
+}
+
+// SetVolatility records the annualised volatility of symbol.
+func (s *Snapshot) SetVolatility(symbol string, sigma float64) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vols[symbol] = sigma
+	return s
+}
+
+// Volatility returns the volatility of symbol and whether it is known.
+func (s *Snapshot) Volatility(symbol string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.vols[symbol]
+	return v, ok
+}
+
+// VolSurface gives the Black-Scholes implied volatility of an underlying
+// by expiry, in years from the snapshot's valuation time, and strike.
+type VolSurface interface {
+	Volatility(expiry, strike float64) (float64, error)
+}
+
+// SetVolSurface records the implied volatility surface of symbol. Pricers
+// prefer it to a flat volatility recorded with SetVolatility.
+func (s *Snapshot) SetVolSurface(symbol string, surface VolSurface) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.surfaces[symbol] = surface
+	return s
+}
+
+// VolSurface returns the volatility surface of symbol and whether it is
+// known.
+func (s *Snapshot) VolSurface(symbol string) (VolSurface, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.surfaces[symbol]
+	return v, ok
+}
+
+// SetNormalVolSurface records a surface of normal (Bachelier) volatilities
+// of symbol. Pricers prefer it to a flat volatility recorded with
+// SetNormalVolatility.
+func (s *Snapshot) SetNormalVolSurface(symbol string, surface VolSurface) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.normSurfs[symbol] = surface
+	return s
+}
+
+// NormalVolSurface returns the normal volatility surface of symbol and
+// whether it is known.
+func (s *Snapshot) NormalVolSurface(symbol string) (VolSurface, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.normSurfs[symbol]
+	return v, ok
+}
+
+// SetNormalVolatility records the annualised normal (Bachelier) volatility
+// of symbol, in price or rate units rather than as a fraction of the level.
+func (s *Snapshot) SetNormalVolatility(symbol string, sigma float64) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.normVols[symbol] = sigma
+	return s
+}
+
+// NormalVolatility returns the normal volatility of symbol and whether it
+// is known.
+func (s *Snapshot) NormalVolatility(symbol string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.normVols[symbol]
+	return v, ok
+}
+
+// SetDividendYield records the continuous dividend yield of symbol.
+func (s *Snapshot) SetDividendYield(symbol string, q float64) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dividends[symbol] = q
+	return s
+}
+
+// DividendYield returns the continuous dividend yield of symbol, or zero.
+func (s *Snapshot) DividendYield(symbol string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dividends[symbol]
+}
+
+// SetCorrelation records the correlation of the returns of symbols a and b.
+func (s *Snapshot) SetCorrelation(a, b string, rho float64) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.corrs[pairKey(a, b)] = rho
+	return s
+}
+
+// Correlation returns the correlation of symbols a and b and whether it is
+// known. A symbol is perfectly correlated with itself.
+func (s *Snapshot) Correlation(a, b string) (float64, bool) {
+	if a == b {
+		return 1, true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rho, ok := s.corrs[pairKey(a, b)]
+	return rho, ok
+}
+
+// pairKey orders a pair of symbols so that both orders share an entry.
+func pairKey(a, b string) [2]string {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
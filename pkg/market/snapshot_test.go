@@ -0,0 +1,103 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubProvider map[string]float64
+
+func (p stubProvider) GetPrice(_ context.Context, symbol string) (Price, error) {
+	v, ok := p[symbol]
+	if !ok {
+		return Price{}, errors.New("unknown symbol")
+	}
+	return Price{Symbol: symbol, Value: decimal.NewFromFloat(v), Timestamp: time.Now()}, nil
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	p := stubProvider{"AAPL": 187.5, "MSFT": 410.25}
+
+	snap, err := LoadSnapshot(context.Background(), p, "AAPL", "MSFT")
+	assert.NoError(t, err)
+
+	spot, ok := snap.Spot("AAPL")
+	assert.True(t, ok)
+	assert.Equal(t, 187.5, spot)
+
+	_, ok = snap.Spot("GOOG")
+	assert.False(t, ok)
+
+	_, err = LoadSnapshot(context.Background(), p, "AAPL", "GOOG")
+	assert.Error(t, err)
+}
+
+func TestSnapshotOptionalInputs(t *testing.T) {
+	snap := NewSnapshot(time.Now())
+
+	_, ok := snap.RiskFreeRate()
+	assert.False(t, ok)
+	assert.Equal(t, 0.0, snap.DividendYield("AAPL"))
+
+	snap.SetRiskFreeRate(0.04).SetVolatility("AAPL", 0.25).SetDividendYield("AAPL", 0.005)
+
+	r, ok := snap.RiskFreeRate()
+	assert.True(t, ok)
+	assert.Equal(t, 0.04, r)
+
+	vol, ok := snap.Volatility("AAPL")
+	assert.True(t, ok)
+	assert.Equal(t, 0.25, vol)
+	assert.Equal(t, 0.005, snap.DividendYield("AAPL"))
+
+	_, ok = snap.NormalVolatility("AAPL")
+	assert.False(t, ok, "lognormal and normal volatilities are separate")
+	snap.SetNormalVolatility("EUR6M", 0.0085)
+	nvol, ok := snap.NormalVolatility("EUR6M")
+	assert.True(t, ok)
+	assert.Equal(t, 0.0085, nvol)
+
+	_, ok = snap.VolSurface("AAPL")
+	assert.False(t, ok)
+	snap.SetVolSurface("AAPL", flatSurface(0.3))
+	surface, ok := snap.VolSurface("AAPL")
+	assert.True(t, ok)
+	vol, err := surface.Volatility(1, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.3, vol)
+
+	_, ok = snap.NormalVolSurface("AAPL")
+	assert.False(t, ok, "lognormal and normal surfaces are separate")
+	snap.SetNormalVolSurface("EUR6M", flatSurface(0.009))
+	surface, ok = snap.NormalVolSurface("EUR6M")
+	assert.True(t, ok)
+	vol, err = surface.Volatility(1, -0.001)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.009, vol)
+}
+
+type flatSurface float64
+
+func (f flatSurface) Volatility(expiry, strike float64) (float64, error) {
+	return float64(f), nil
+}
+
+func TestSnapshotCorrelation(t *testing.T) {
+	snap := NewSnapshot(time.Now()).SetCorrelation("AAPL", "MSFT", 0.6)
+
+	rho, ok := snap.Correlation("MSFT", "AAPL")
+	assert.True(t, ok)
+	assert.Equal(t, 0.6, rho)
+
+	rho, ok = snap.Correlation("GOOG", "GOOG")
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, rho)
+
+	_, ok = snap.Correlation("AAPL", "GOOG")
+	assert.False(t, ok)
+}
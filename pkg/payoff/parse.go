@@ -0,0 +1,435 @@
+package payoff
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+// ErrSyntax is wrapped by every SyntaxError.
+var ErrSyntax = errors.New("payoff: syntax error")
+
+// SyntaxError reports why and where an expression failed to parse.
+type SyntaxError struct {
+	Expr string
+	Pos  int // byte offset into Expr
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("payoff: %s at offset %d of %q", e.Msg, e.Pos, e.Expr)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return ErrSyntax
+}
+
+// Parse compiles a payoff expression. Expressions combine numbers, the
+// names below and the entries of params with
+//
+//	^ * / + -              arithmetic, ^ binding tightest
+//	< <= > >= == !=        comparisons, 1 when true and 0 otherwise
+//	&& || !                logic on positive (true) and other values
+//
+// The built-in names are
+//
+//	S                      the observed path; as a number, the spot at expiry
+//	S[i]                   the spot at observation i, negative i from the end
+//	S0                     the spot at the valuation time
+//	T                      the time to expiry in years
+//	avg(S) gavg(S)         arithmetic and geometric averages of the path
+//	max(S) min(S)          extremes of the path
+//	max(a, b, ...) min(a, b, ...)
+//	abs(x) exp(x) log(x) sqrt(x)
+//	if(cond, a, b)         a where cond is true, b otherwise
+//
+// so an arithmetic Asian call is "max(avg(S) - K, 0)" with params {"K": 100}
+// and an up-and-out call "if(max(S) < B, max(S - K, 0), 0)".
+func Parse(expr string, params map[string]float64) (Payoff, error) {
+	for name := range params {
+		if _, ok := builtins[name]; ok || name == "S" || name == "S0" || name == "T" {
+			return nil, &SyntaxError{Expr: expr, Msg: fmt.Sprintf("parameter %q shadows a built-in name", name)}
+		}
+	}
+	p := &parser{expr: expr, params: params}
+	p.next()
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %q", p.tok.text)
+	}
+	return scalarOf(n), nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// node is a compiled subexpression. Series nodes stand for the whole path
+// and only become numbers through an aggregate or indexing.
+type node struct {
+	payoff Payoff
+	series bool
+}
+
+type parser struct {
+	expr   string
+	params map[string]float64
+	pos    int
+	tok    token
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Expr: p.expr, Pos: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next advances to the following token.
+func (p *parser) next() {
+	for p.pos < len(p.expr) && unicode.IsSpace(rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos == len(p.expr) {
+		p.tok = token{kind: tokEOF, text: "end of expression", pos: start}
+		return
+	}
+
+	c := p.expr[p.pos]
+	switch {
+	case isDigit(c) || c == '.':
+		for p.pos < len(p.expr) && (isDigit(p.expr[p.pos]) || p.expr[p.pos] == '.') {
+			p.pos++
+		}
+		if p.pos < len(p.expr) && (p.expr[p.pos] == 'e' || p.expr[p.pos] == 'E') {
+			p.pos++
+			if p.pos < len(p.expr) && (p.expr[p.pos] == '+' || p.expr[p.pos] == '-') {
+				p.pos++
+			}
+			for p.pos < len(p.expr) && isDigit(p.expr[p.pos]) {
+				p.pos++
+			}
+		}
+		p.tok = token{kind: tokNumber, text: p.expr[start:p.pos], pos: start}
+	case isLetter(c):
+		for p.pos < len(p.expr) && (isLetter(p.expr[p.pos]) || isDigit(p.expr[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: tokIdent, text: p.expr[start:p.pos], pos: start}
+	default:
+		p.pos++
+		if p.pos < len(p.expr) {
+			switch two := p.expr[start : p.pos+1]; two {
+			case "<=", ">=", "==", "!=", "&&", "||":
+				p.pos++
+				p.tok = token{kind: tokOp, text: two, pos: start}
+				return
+			}
+		}
+		p.tok = token{kind: tokOp, text: p.expr[start:p.pos], pos: start}
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// accept consumes the operator op if it is next.
+func (p *parser) accept(op string) bool {
+	if p.tok.kind == tokOp && p.tok.text == op {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		return p.errorf("expected %q, found %q", op, p.tok.text)
+	}
+	return nil
+}
+
+// binary parses a left-associative chain of the operators in ops over
+// operands parsed by operand.
+func (p *parser) binary(operand func() (node, error), ops map[string]func(a, b float64) float64) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return node{}, err
+	}
+	for p.tok.kind == tokOp && ops[p.tok.text] != nil {
+		op := ops[p.tok.text]
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return node{}, err
+		}
+		a, b := scalarOf(left), scalarOf(right)
+		left = node{payoff: Func(func(path Path) float64 { return op(a.Evaluate(path), b.Evaluate(path)) })}
+	}
+	return left, nil
+}
+
+func truth(ok bool) float64 {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.binary(p.parseAnd, map[string]func(a, b float64) float64{
+		"||": func(a, b float64) float64 { return truth(a > 0 || b > 0) },
+	})
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.binary(p.parseComparison, map[string]func(a, b float64) float64{
+		"&&": func(a, b float64) float64 { return truth(a > 0 && b > 0) },
+	})
+}
+
+func (p *parser) parseComparison() (node, error) {
+	return p.binary(p.parseSum, map[string]func(a, b float64) float64{
+		"<":  func(a, b float64) float64 { return truth(a < b) },
+		"<=": func(a, b float64) float64 { return truth(a <= b) },
+		">":  func(a, b float64) float64 { return truth(a > b) },
+		">=": func(a, b float64) float64 { return truth(a >= b) },
+		"==": func(a, b float64) float64 { return truth(a == b) },
+		"!=": func(a, b float64) float64 { return truth(a != b) },
+	})
+}
+
+func (p *parser) parseSum() (node, error) {
+	return p.binary(p.parseProduct, map[string]func(a, b float64) float64{
+		"+": func(a, b float64) float64 { return a + b },
+		"-": func(a, b float64) float64 { return a - b },
+	})
+}
+
+func (p *parser) parseProduct() (node, error) {
+	return p.binary(p.parseUnary, map[string]func(a, b float64) float64{
+		"*": func(a, b float64) float64 { return a * b },
+		"/": func(a, b float64) float64 { return a / b },
+	})
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch {
+	case p.accept("-"):
+		n, err := p.parseUnary()
+		if err != nil {
+			return node{}, err
+		}
+		return node{payoff: Apply(func(x float64) float64 { return -x }, scalarOf(n))}, nil
+	case p.accept("+"):
+		return p.parseUnary()
+	case p.accept("!"):
+		n, err := p.parseUnary()
+		if err != nil {
+			return node{}, err
+		}
+		return node{payoff: Apply(func(x float64) float64 { return truth(x <= 0) }, scalarOf(n))}, nil
+	}
+	return p.parsePower()
+}
+
+// parsePower parses a ^ b, which is right-associative and binds tighter
+// than unary minus on its left: -2^2 is -4.
+func (p *parser) parsePower() (node, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return node{}, err
+	}
+	if !p.accept("^") {
+		return base, nil
+	}
+	exp, err := p.parseUnary()
+	if err != nil {
+		return node{}, err
+	}
+	a, b := scalarOf(base), scalarOf(exp)
+	return node{payoff: Func(func(path Path) float64 { return math.Pow(a.Evaluate(path), b.Evaluate(path)) })}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return node{}, p.errorf("malformed number %q", tok.text)
+		}
+		p.next()
+		return node{payoff: Const(v)}, nil
+	case tokIdent:
+		p.next()
+		if p.tok.kind == tokOp && p.tok.text == "(" {
+			return p.parseCall(tok)
+		}
+		return p.parseName(tok)
+	case tokOp:
+		if p.accept("(") {
+			n, err := p.parseOr()
+			if err != nil {
+				return node{}, err
+			}
+			return n, p.expect(")")
+		}
+	}
+	return node{}, p.errorf("unexpected %q", tok.text)
+}
+
+// parseName resolves a name that is not called as a function.
+func (p *parser) parseName(tok token) (node, error) {
+	switch tok.text {
+	case "S":
+		if !p.accept("[") {
+			return node{payoff: Spot(), series: true}, nil
+		}
+		sign := 1
+		if p.accept("-") {
+			sign = -1
+		}
+		i, err := strconv.Atoi(p.tok.text)
+		if p.tok.kind != tokNumber || err != nil {
+			return node{}, p.errorf("expected an integer index, found %q", p.tok.text)
+		}
+		p.next()
+		return node{payoff: checkedFixing(sign * i)}, p.expect("]")
+	case "S0":
+		return node{payoff: Initial()}, nil
+	case "T":
+		return node{payoff: Func(func(path Path) float64 { return path.Times[len(path.Times)-1] })}, nil
+	}
+	if v, ok := p.params[tok.text]; ok {
+		return node{payoff: Const(v)}, nil
+	}
+	if _, ok := builtins[tok.text]; ok {
+		return node{}, &SyntaxError{Expr: p.expr, Pos: tok.pos, Msg: fmt.Sprintf("%s must be called", tok.text)}
+	}
+	return node{}, &SyntaxError{Expr: p.expr, Pos: tok.pos, Msg: fmt.Sprintf("unknown name %q", tok.text)}
+}
+
+// checkedFixing is Fixing that evaluates to NaN instead of panicking when
+// the path has too few observations.
+func checkedFixing(i int) Payoff {
+	fixing := Fixing(i)
+	return Func(func(path Path) float64 {
+		if i >= len(path.Spots) || -i > len(path.Spots) {
+			return math.NaN()
+		}
+		return fixing.Evaluate(path)
+	})
+}
+
+// builtin compiles a call from its arguments.
+type builtin func(args []node) (Payoff, error)
+
+var builtins = map[string]builtin{
+	"abs":  unaryBuiltin(math.Abs),
+	"exp":  unaryBuiltin(math.Exp),
+	"log":  unaryBuiltin(math.Log),
+	"sqrt": unaryBuiltin(math.Sqrt),
+	"avg":  aggregateBuiltin(Average),
+	"gavg": aggregateBuiltin(GeometricAverage),
+	"max":  extremeBuiltin(Maximum, Max),
+	"min":  extremeBuiltin(Minimum, Min),
+	"if": func(args []node) (Payoff, error) {
+		if len(args) != 3 {
+			return nil, fmt.Errorf("takes three arguments, got %d", len(args))
+		}
+		return If(scalarOf(args[0]), scalarOf(args[1]), scalarOf(args[2])), nil
+	},
+}
+
+// scalarOf uses a series as its value at expiry.
+func scalarOf(n node) Payoff {
+	if n.series {
+		return Spot()
+	}
+	return n.payoff
+}
+
+func unaryBuiltin(f func(float64) float64) builtin {
+	return func(args []node) (Payoff, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("takes one argument, got %d", len(args))
+		}
+		return Apply(f, scalarOf(args[0])), nil
+	}
+}
+
+func aggregateBuiltin(f func() Payoff) builtin {
+	return func(args []node) (Payoff, error) {
+		if len(args) != 1 || !args[0].series {
+			return nil, errors.New("takes the path S")
+		}
+		return f(), nil
+	}
+}
+
+// extremeBuiltin aggregates the path when given S alone and otherwise
+// compares its arguments.
+func extremeBuiltin(path func() Payoff, scalar func(...Payoff) Payoff) builtin {
+	return func(args []node) (Payoff, error) {
+		if len(args) == 1 && args[0].series {
+			return path(), nil
+		}
+		if len(args) < 2 {
+			return nil, errors.New("takes the path S or at least two arguments")
+		}
+		ps := make([]Payoff, len(args))
+		for i, a := range args {
+			ps[i] = scalarOf(a)
+		}
+		return scalar(ps...), nil
+	}
+}
+
+// parseCall parses the argument list of the function named by tok.
+func (p *parser) parseCall(tok token) (node, error) {
+	fn, ok := builtins[tok.text]
+	if !ok {
+		return node{}, &SyntaxError{Expr: p.expr, Pos: tok.pos, Msg: fmt.Sprintf("unknown function %q", tok.text)}
+	}
+	if err := p.expect("("); err != nil {
+		return node{}, err
+	}
+	var args []node
+	for !p.accept(")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return node{}, err
+			}
+		}
+		arg, err := p.parseOr()
+		if err != nil {
+			return node{}, err
+		}
+		args = append(args, arg)
+	}
+	payoff, err := fn(args)
+	if err != nil {
+		return node{}, &SyntaxError{Expr: p.expr, Pos: tok.pos, Msg: fmt.Sprintf("%s %v", tok.text, err)}
+	}
+	return node{payoff: payoff}, nil
+}
@@ -0,0 +1,92 @@
+package payoff
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	params := map[string]float64{"K": 100, "B": 115, "N": 10}
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"max(avg(S)-K,0)", 6.25},
+		{"max(S - K, 0)", 5},
+		{"max(K - S, 0)", 0},
+		{"max(S) - min(S)", 30},
+		{"gavg(S)", math.Pow(110*90*120*105, 0.25)},
+		{"S[0] + S[-1]", 215},
+		{"S[2]/S0 - 1", 0.2},
+		{"T", 1},
+		{"N * (S > K)", 10},
+		{"if(max(S) < B, max(S - K, 0), 0)", 0},
+		{"if(max(S) < B || S > K, 1, 2)", 1},
+		{"if(max(S) < B && S > K, 1, 2)", 2},
+		{"!(S > K)", 0},
+		{"2 + 3 * 4", 14},
+		{"(2 + 3) * 4", 20},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2 ^ 2", -4},
+		{"2 ^ -1", 0.5},
+		{"10 - 4 - 3", 3},
+		{"1.5e2 / 3", 50},
+		{"abs(S0 - S) + sqrt(16) + log(exp(2))", 11},
+		{"min(S, K, 99)", 99},
+		{"S <= 105 && S >= 105 && S == 105 && S != 104", 1},
+	}
+	for _, tt := range tests {
+		p, err := Parse(tt.expr, params)
+		if !assert.NoError(t, err, tt.expr) {
+			continue
+		}
+		assert.InDelta(t, tt.want, p.Evaluate(testPath), 1e-12, tt.expr)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	params := map[string]float64{"K": 100}
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{"max(S - K, 0", 12},
+		{"max(S - K 0)", 10},
+		{"avg(K)", 0},
+		{"max(K)", 0},
+		{"foo(S)", 0},
+		{"S - X", 4},
+		{"S[1.5]", 2},
+		{"avg", 0},
+		{"S + * 2", 4},
+		{"S ) ", 2},
+		{"1.2.3", 0},
+		{"", 0},
+		{"if(S, 1)", 0},
+		{"exp(1, 2)", 0},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.expr, params)
+		var syntaxErr *SyntaxError
+		if assert.True(t, errors.As(err, &syntaxErr), tt.expr) {
+			assert.Equal(t, tt.pos, syntaxErr.Pos, "%s: %v", tt.expr, err)
+		}
+		assert.True(t, errors.Is(err, ErrSyntax), tt.expr)
+	}
+
+	_, err := Parse("S - T", map[string]float64{"T": 1})
+	assert.ErrorIs(t, err, ErrSyntax)
+}
+
+func TestParse_IndexOutOfRangeIsNaN(t *testing.T) {
+	p, err := Parse("S[4]", nil)
+	assert.NoError(t, err)
+	assert.True(t, math.IsNaN(p.Evaluate(testPath)))
+
+	p, err = Parse("S[-4]", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 110.0, p.Evaluate(testPath))
+}
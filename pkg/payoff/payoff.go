@@ -0,0 +1,168 @@
+// Package payoff describes the cash flow of a derivative as a function of
+// the simulated path of its underlying. Payoffs are built from small
+// composable pieces, either in Go or by parsing an expression such as
+// "max(avg(S)-K, 0)", and are evaluated by the Monte Carlo pricer.
+package payoff
+
+import "math"
+
+// Path is one simulated trajectory of the underlying.
+type Path struct {
+	// Initial is the spot at the valuation time.
+	Initial float64
+	// Times are the increasing observation times in years; the last one
+	// is the expiry.
+	Times []float64
+	// Spots holds the spot at each observation time.
+	Spots []float64
+}
+
+// Payoff maps a path to the amount paid at expiry.
+type Payoff interface {
+	Evaluate(p Path) float64
+}
+
+// Func adapts an ordinary function to Payoff.
+type Func func(p Path) float64
+
+func (f Func) Evaluate(p Path) float64 {
+	return f(p)
+}
+
+// Const pays v on every path.
+func Const(v float64) Payoff {
+	return Func(func(Path) float64 { return v })
+}
+
+// Spot is the spot at expiry.
+func Spot() Payoff {
+	return Func(func(p Path) float64 { return p.Spots[len(p.Spots)-1] })
+}
+
+// Initial is the spot at the valuation time.
+func Initial() Payoff {
+	return Func(func(p Path) float64 { return p.Initial })
+}
+
+// Fixing is the spot at observation i, counting from zero. Negative i
+// count back from the expiry, so Fixing(-1) is Spot().
+func Fixing(i int) Payoff {
+	return Func(func(p Path) float64 {
+		if i < 0 {
+			return p.Spots[len(p.Spots)+i]
+		}
+		return p.Spots[i]
+	})
+}
+
+// Average is the arithmetic average of the observed spots.
+func Average() Payoff {
+	return Func(func(p Path) float64 {
+		sum := 0.0
+		for _, s := range p.Spots {
+			sum += s
+		}
+		return sum / float64(len(p.Spots))
+	})
+}
+
+// GeometricAverage is the geometric average of the observed spots.
+func GeometricAverage() Payoff {
+	return Func(func(p Path) float64 {
+		logSum := 0.0
+		for _, s := range p.Spots {
+			logSum += math.Log(s)
+		}
+		return math.Exp(logSum / float64(len(p.Spots)))
+	})
+}
+
+// Maximum is the highest observed spot.
+func Maximum() Payoff {
+	return Func(func(p Path) float64 {
+		hi := math.Inf(-1)
+		for _, s := range p.Spots {
+			hi = math.Max(hi, s)
+		}
+		return hi
+	})
+}
+
+// Minimum is the lowest observed spot.
+func Minimum() Payoff {
+	return Func(func(p Path) float64 {
+		lo := math.Inf(1)
+		for _, s := range p.Spots {
+			lo = math.Min(lo, s)
+		}
+		return lo
+	})
+}
+
+// Add pays a plus b.
+func Add(a, b Payoff) Payoff {
+	return Func(func(p Path) float64 { return a.Evaluate(p) + b.Evaluate(p) })
+}
+
+// Sub pays a minus b.
+func Sub(a, b Payoff) Payoff {
+	return Func(func(p Path) float64 { return a.Evaluate(p) - b.Evaluate(p) })
+}
+
+// Mul pays a times b.
+func Mul(a, b Payoff) Payoff {
+	return Func(func(p Path) float64 { return a.Evaluate(p) * b.Evaluate(p) })
+}
+
+// Div pays a divided by b.
+func Div(a, b Payoff) Payoff {
+	return Func(func(p Path) float64 { return a.Evaluate(p) / b.Evaluate(p) })
+}
+
+// Max pays the largest of ps.
+func Max(ps ...Payoff) Payoff {
+	return Func(func(p Path) float64 {
+		v := math.Inf(-1)
+		for _, x := range ps {
+			v = math.Max(v, x.Evaluate(p))
+		}
+		return v
+	})
+}
+
+// Min pays the smallest of ps.
+func Min(ps ...Payoff) Payoff {
+	return Func(func(p Path) float64 {
+		v := math.Inf(1)
+		for _, x := range ps {
+			v = math.Min(v, x.Evaluate(p))
+		}
+		return v
+	})
+}
+
+// Apply pays f of a, for example math.Exp or math.Abs.
+func Apply(f func(float64) float64, a Payoff) Payoff {
+	return Func(func(p Path) float64 { return f(a.Evaluate(p)) })
+}
+
+// If pays then on paths where cond is positive and otherwise otherwise.
+// Only the selected branch is evaluated.
+func If(cond, then, otherwise Payoff) Payoff {
+	return Func(func(p Path) float64 {
+		if cond.Evaluate(p) > 0 {
+			return then.Evaluate(p)
+		}
+		return otherwise.Evaluate(p)
+	})
+}
+
+// Call pays max(a - k, 0).
+func Call(a Payoff, k float64) Payoff {
+	return Max(Sub(a, Const(k)), Const(0))
+}
+
+// Put pays max(k - a, 0).
+func Put(a Payoff, k float64) Payoff {
+	return Max(Sub(Const(k), a), Const(0))
+}
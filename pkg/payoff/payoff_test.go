@@ -0,0 +1,43 @@
+package payoff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPath = Path{
+	Initial: 100,
+	Times:   []float64{0.25, 0.5, 0.75, 1},
+	Spots:   []float64{110, 90, 120, 105},
+}
+
+func TestAggregates(t *testing.T) {
+	assert.Equal(t, 105.0, Spot().Evaluate(testPath))
+	assert.Equal(t, 100.0, Initial().Evaluate(testPath))
+	assert.Equal(t, 110.0, Fixing(0).Evaluate(testPath))
+	assert.Equal(t, 120.0, Fixing(-2).Evaluate(testPath))
+	assert.Equal(t, 106.25, Average().Evaluate(testPath))
+	assert.InDelta(t, math.Pow(110*90*120*105, 0.25), GeometricAverage().Evaluate(testPath), 1e-12)
+	assert.Equal(t, 120.0, Maximum().Evaluate(testPath))
+	assert.Equal(t, 90.0, Minimum().Evaluate(testPath))
+}
+
+func TestCombinators(t *testing.T) {
+	assert.Equal(t, 5.0, Call(Spot(), 100).Evaluate(testPath))
+	assert.Equal(t, 0.0, Put(Spot(), 100).Evaluate(testPath))
+	assert.Equal(t, 6.25, Call(Average(), 100).Evaluate(testPath))
+	assert.Equal(t, 30.0, Sub(Maximum(), Minimum()).Evaluate(testPath))
+	assert.Equal(t, 1.05, Div(Spot(), Initial()).Evaluate(testPath))
+	assert.Equal(t, 7.0, Add(Const(3), Mul(Const(2), Const(2))).Evaluate(testPath))
+	assert.Equal(t, 120.0, Max(Spot(), Fixing(2), Const(-1)).Evaluate(testPath))
+	assert.Equal(t, -1.0, Min(Spot(), Fixing(2), Const(-1)).Evaluate(testPath))
+	assert.Equal(t, 5.0, Apply(math.Abs, Sub(Initial(), Spot())).Evaluate(testPath))
+
+	// An up-and-out call knocked out by the 120 fixing
+	knockOut := If(Sub(Const(115), Maximum()), Call(Spot(), 100), Const(0))
+	assert.Equal(t, 0.0, knockOut.Evaluate(testPath))
+	knockOut = If(Sub(Const(125), Maximum()), Call(Spot(), 100), Const(0))
+	assert.Equal(t, 5.0, knockOut.Evaluate(testPath))
+}
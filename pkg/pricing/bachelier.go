@@ -0,0 +1,253 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+)
+
+// BachelierPricer implements the Bachelier (normal) model for European
+// options on forwards that may be zero or negative, such as interest
+// rates. As for Black76Pricer, the underlying's quote in the market
+// snapshot is its forward. Volatility is a normal volatility, in units of
+// the forward per square root of a year; the snapshot's normal volatility
+// surface, or else its flat normal volatility, takes precedence over it,
+// and lognormal quotes and surfaces are ignored.
+type BachelierPricer struct {
+	Market       *market.Snapshot
+	RiskFreeRate float64
+	Volatility   float64
+}
+
+// NewBachelierPricer creates a new Bachelier pricer valuing against mkt.
+func NewBachelierPricer(mkt *market.Snapshot, r, normalVol float64) *BachelierPricer {
+	return &BachelierPricer{
+		Market:       mkt,
+		RiskFreeRate: r,
+		Volatility:   normalVol,
+	}
+}
+
+var (
+	_ Pricer           = (*BachelierPricer)(nil)
+	_ GreeksCalculator = (*BachelierPricer)(nil)
+)
+
+// Price values a European option on a forward in closed form. The reported
+// Inputs.Spot is the forward and Inputs.Volatility the normal volatility.
+func (bp *BachelierPricer) Price(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+	opt, in, err := bp.resolve(ctx, inst)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	return PricingResult{
+		Price:  money.NewFromFloat(bachelierPrice(in, opt.OptionType()), opt.Currency()),
+		Model:  ModelBachelier,
+		Inputs: in,
+	}, nil
+}
+
+// Greeks returns the analytic Bachelier sensitivities. Delta and Gamma are
+// taken with respect to the forward, Vega and Volga with respect to the
+// normal volatility, and Rho holds the forward fixed.
+func (bp *BachelierPricer) Greeks(ctx context.Context, inst instrument.Instrument) (Greeks, error) {
+	opt, in, err := bp.resolve(ctx, inst)
+	if err != nil {
+		return Greeks{}, err
+	}
+	return bachelierGreeks(in, opt.OptionType()), nil
+}
+
+// ImpliedVolatility backs out the normal volatility implied by premium.
+func (bp *BachelierPricer) ImpliedVolatility(ctx context.Context, inst instrument.Instrument, premium float64) (float64, error) {
+	opt, in, err := bp.resolve(ctx, inst)
+	if err != nil {
+		return 0, err
+	}
+	return NormalImpliedVolatility(premium, in, opt.OptionType())
+}
+
+func (bp *BachelierPricer) resolve(ctx context.Context, inst instrument.Instrument) (*instrument.Option, Inputs, error) {
+	opt, err := asEuropeanOption(inst)
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, Inputs{}, err
+	}
+	// Lognormal volatility surfaces do not apply either
+	in, err := quotedInputs(bp.Market, opt, opt.Strike().InexactFloat64(), bp.RiskFreeRate, bp.Volatility)
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	in.DividendYield = 0
+	in.Dividends = nil
+	in.Volatility = bp.Volatility
+	symbol := symbolOf(opt.Underlying())
+	if vol, ok := bp.Market.NormalVolatility(symbol); ok {
+		in.Volatility = vol
+	}
+	if surface, ok := bp.Market.NormalVolSurface(symbol); ok && in.Expiry > 0 && !math.IsInf(in.Expiry, 0) {
+		vol, err := surface.Volatility(in.Expiry, in.Strike)
+		if err != nil {
+			return nil, Inputs{}, fmt.Errorf("%w: normal volatility of %s: %w", ErrMissingMarketData, symbol, err)
+		}
+		in.Volatility = vol
+	}
+	if err := validateNormal(in); err != nil {
+		return nil, Inputs{}, err
+	}
+	return opt, in, nil
+}
+
+// validateNormal checks the inputs are inside the domain of the Bachelier
+// model, where the forward and strike may take any sign.
+func validateNormal(in Inputs) error {
+	if err := finite("expiry", in.Expiry); err != nil {
+		return err
+	}
+	if in.Expiry <= 0 {
+		return fmt.Errorf("%w: %.6f years to expiry", ErrExpired, in.Expiry)
+	}
+	if err := finite("forward", in.Spot); err != nil {
+		return err
+	}
+	if err := finite("strike", in.Strike); err != nil {
+		return err
+	}
+	if err := positive("volatility", in.Volatility); err != nil {
+		return err
+	}
+	return finite("rate", in.RiskFreeRate)
+}
+
+// bachelierPrice returns the Bachelier value of a European option on the
+// forward in.Spot,
+//
+//	C = e^{-rT} [(F-K) N(d) + sigma sqrt(T) n(d)],  d = (F-K) / (sigma sqrt(T))
+//
+// and puts by put-call parity.
+func bachelierPrice(in Inputs, optType instrument.OptionType) float64 {
+	F, K, T := in.Spot, in.Strike, in.Expiry
+	df := math.Exp(-in.RiskFreeRate * T)
+	sd := in.Volatility * math.Sqrt(T)
+	d := (F - K) / sd
+
+	if optType == instrument.Call {
+		return df * ((F-K)*normCdf(d) + sd*normPdf(d))
+	}
+	return df * ((K-F)*normCdf(-d) + sd*normPdf(d))
+}
+
+// bachelierGreeks returns the Bachelier sensitivities of a European option.
+func bachelierGreeks(in Inputs, optType instrument.OptionType) Greeks {
+	T, sigma, r := in.Expiry, in.Volatility, in.RiskFreeRate
+	sqrtT := math.Sqrt(T)
+	df := math.Exp(-r * T)
+	d := (in.Spot - in.Strike) / (sigma * sqrtT)
+	pdf := normPdf(d)
+	price := bachelierPrice(in, optType)
+
+	g := Greeks{
+		Delta: df * normCdf(d),
+		Gamma: df * pdf / (sigma * sqrtT),
+		Vega:  df * sqrtT * pdf,
+		Theta: r*price - df*sigma*pdf/(2*sqrtT),
+		Rho:   -T * price,
+		Vanna: -df * pdf * d / sigma,
+	}
+	if optType == instrument.Put {
+		g.Delta = -df * normCdf(-d)
+	}
+	g.Volga = g.Vega * d * d / sigma
+	g.Charm = r*g.Delta + df*pdf*d/(2*T)
+	return g
+}
+
+// NormalImpliedVolatility returns the Bachelier volatility at which an
+// option on the forward in.Spot is worth premium. in.Volatility, when
+// positive, is used as the starting guess. Like ImpliedVolatility it runs
+// Newton-Raphson on vega, falling back to Brent's method on a bracket
+// grown until it contains the root.
+func NormalImpliedVolatility(premium float64, in Inputs, optType instrument.OptionType) (float64, error) {
+	guess := in.Volatility
+	in.Volatility = 1 // validated separately as the unknown
+	if err := validateNormal(in); err != nil {
+		return 0, err
+	}
+	if err := finite("premium", premium); err != nil {
+		return 0, err
+	}
+
+	df := math.Exp(-in.RiskFreeRate * in.Expiry)
+	intrinsic := df * math.Max(in.Spot-in.Strike, 0)
+	if optType == instrument.Put {
+		intrinsic = df * math.Max(in.Strike-in.Spot, 0)
+	}
+	if premium <= intrinsic {
+		return 0, fmt.Errorf("%w: %g not above intrinsic value %g", ErrArbitrageBounds, premium, intrinsic)
+	}
+
+	objective := func(sigma float64) float64 {
+		x := in
+		x.Volatility = sigma
+		return bachelierPrice(x, optType) - premium
+	}
+
+	// An at-the-money option is worth df sigma sqrt(T/2pi); its time value
+	// gives the starting point when there is no guess
+	sigma := guess
+	if !(sigma > 0) || math.IsInf(sigma, 0) {
+		sigma = (premium - intrinsic) / df * math.Sqrt(2*math.Pi/in.Expiry)
+	}
+	lo, hi := 0.0, math.Inf(1)
+	for i := 0; i < impliedVolMaxIter; i++ {
+		x := in
+		x.Volatility = sigma
+		diff := bachelierPrice(x, optType) - premium
+		if math.Abs(diff) < impliedVolTolerance {
+			return sigma, nil
+		}
+		if diff > 0 {
+			hi = sigma
+		} else {
+			lo = sigma
+		}
+
+		vega := bachelierGreeks(x, optType).Vega
+		if vega < 1e-12 {
+			break
+		}
+		next := sigma - diff/vega
+		if next <= lo || next >= hi {
+			break
+		}
+		if math.Abs(next-sigma) < impliedVolTolerance*math.Max(sigma, 1e-4) {
+			return next, nil
+		}
+		sigma = next
+	}
+
+	// Grow the bracket until the price at its top exceeds the premium
+	if math.IsInf(hi, 1) {
+		hi = math.Max(2*sigma, lo)
+		for i := 0; objective(hi) <= 0; i++ {
+			if i == impliedVolMaxIter {
+				return 0, fmt.Errorf("implied normal volatility for premium %g: %w", premium, ErrNoConvergence)
+			}
+			lo, hi = hi, 2*hi
+		}
+	}
+	if lo == 0 {
+		lo = hi * 1e-12
+	}
+	sigma, err := brent(objective, lo, hi, impliedVolTolerance*hi, impliedVolMaxIter)
+	if err != nil {
+		return 0, fmt.Errorf("implied normal volatility for premium %g: %w", premium, err)
+	}
+	return sigma, nil
+}
@@ -0,0 +1,152 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBachelierPrice_MatchesIntegral(t *testing.T) {
+	// Negative forward, strike and rate
+	in := Inputs{Spot: -0.002, Strike: -0.001, Expiry: 2, RiskFreeRate: -0.005, Volatility: 0.006}
+	sd := in.Volatility * math.Sqrt(in.Expiry)
+	df := math.Exp(-in.RiskFreeRate * in.Expiry)
+
+	for _, typ := range []instrument.OptionType{instrument.Call, instrument.Put} {
+		// Integrate the payoff against the normal density of F_T
+		const n = 20000
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			z := -10 + 20*(float64(i)+0.5)/n
+			sum += vanillaPayoff(typ, in.Spot+sd*z, in.Strike) * normPdf(z) * 20 / n
+		}
+		assert.InDelta(t, df*sum, bachelierPrice(in, typ), 1e-9, typ)
+	}
+
+	// Put-call parity and the at-the-money value
+	assert.InDelta(t, df*(in.Spot-in.Strike), bachelierPrice(in, instrument.Call)-bachelierPrice(in, instrument.Put), 1e-15)
+	in.Strike = in.Spot
+	assert.InDelta(t, df*sd/math.Sqrt(2*math.Pi), bachelierPrice(in, instrument.Call), 1e-15)
+}
+
+func TestBachelierGreeks_MatchFiniteDifferences(t *testing.T) {
+	in := Inputs{Spot: 0.012, Strike: 0.015, Expiry: 1.5, RiskFreeRate: 0.02, Volatility: 0.008}
+	const h = 1e-6
+
+	for _, typ := range []instrument.OptionType{instrument.Call, instrument.Put} {
+		g := bachelierGreeks(in, typ)
+
+		bump := func(f func(*Inputs, float64)) (Inputs, Inputs) {
+			up, down := in, in
+			f(&up, h)
+			f(&down, -h)
+			return up, down
+		}
+
+		up, down := bump(func(x *Inputs, d float64) { x.Spot += d })
+		assert.InDelta(t, (bachelierPrice(up, typ)-bachelierPrice(down, typ))/(2*h), g.Delta, 1e-7)
+		assert.InDelta(t, (bachelierGreeks(up, typ).Delta-bachelierGreeks(down, typ).Delta)/(2*h), g.Gamma, 1e-3)
+
+		up, down = bump(func(x *Inputs, d float64) { x.Volatility += d })
+		assert.InDelta(t, (bachelierPrice(up, typ)-bachelierPrice(down, typ))/(2*h), g.Vega, 1e-7)
+		assert.InDelta(t, (bachelierGreeks(up, typ).Delta-bachelierGreeks(down, typ).Delta)/(2*h), g.Vanna, 1e-3)
+		assert.InDelta(t, (bachelierGreeks(up, typ).Vega-bachelierGreeks(down, typ).Vega)/(2*h), g.Volga, 1e-3)
+
+		up, down = bump(func(x *Inputs, d float64) { x.RiskFreeRate += d })
+		assert.InDelta(t, (bachelierPrice(up, typ)-bachelierPrice(down, typ))/(2*h), g.Rho, 1e-7)
+
+		up, down = bump(func(x *Inputs, d float64) { x.Expiry += d })
+		assert.InDelta(t, -(bachelierPrice(up, typ)-bachelierPrice(down, typ))/(2*h), g.Theta, 1e-7)
+		assert.InDelta(t, -(bachelierGreeks(up, typ).Delta-bachelierGreeks(down, typ).Delta)/(2*h), g.Charm, 1e-5)
+	}
+}
+
+func TestNormalImpliedVolatility_RoundTrip(t *testing.T) {
+	for _, typ := range []instrument.OptionType{instrument.Call, instrument.Put} {
+		for _, strike := range []float64{-0.01, -0.002, 0, 0.001, 0.005, 0.03} {
+			for _, sigma := range []float64{0.001, 0.0075, 0.02} {
+				in := Inputs{Spot: 0.001, Strike: strike, Expiry: 3, RiskFreeRate: -0.004, Volatility: sigma}
+				premium := bachelierPrice(in, typ)
+				df := math.Exp(-in.RiskFreeRate * in.Expiry)
+				if premium-df*vanillaPayoff(typ, in.Spot, in.Strike) < 1e-12 {
+					// No vol information left in the premium
+					continue
+				}
+
+				t.Run(fmt.Sprintf("%s/K=%g/sigma=%g", typ, strike, sigma), func(t *testing.T) {
+					in.Volatility = 0 // no starting guess
+					got, err := NormalImpliedVolatility(premium, in, typ)
+					assert.NoError(t, err)
+					assert.InDelta(t, sigma, got, 1e-7*math.Max(1, sigma/premium))
+				})
+			}
+		}
+	}
+}
+
+func TestNormalImpliedVolatility_Errors(t *testing.T) {
+	in := Inputs{Spot: 0.01, Strike: 0.005, Expiry: 1}
+
+	_, err := NormalImpliedVolatility(0.004, in, instrument.Call)
+	assert.ErrorIs(t, err, ErrArbitrageBounds)
+
+	_, err = NormalImpliedVolatility(math.Inf(1), in, instrument.Call)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	in.Expiry = 0
+	_, err = NormalImpliedVolatility(0.01, in, instrument.Call)
+	assert.ErrorIs(t, err, ErrExpired)
+}
+
+func TestBachelierPricer_MarketData(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).
+		SetSpot("EUR6M", -0.0025).
+		SetVolatility("EUR6M", 0.4). // lognormal quotes do not apply
+		SetRiskFreeRate(-0.004)
+	fra := instrument.NewFuture("EUR6M-1Y", "EUR", "EUR6M", yearsFrom(now, 1))
+	floorlet := instrument.NewEuropeanOption("FLOORLET", fra, decimal.NewFromFloat(-0.002), yearsFrom(now, 1), instrument.Put)
+
+	pricer := NewBachelierPricer(mkt, 0.02, 0.005)
+	res, err := pricer.Price(context.Background(), floorlet)
+	assert.NoError(t, err)
+	assert.Equal(t, ModelBachelier, res.Model)
+	assert.Equal(t, 0.005, res.Inputs.Volatility)
+	assert.Equal(t, -0.004, res.Inputs.RiskFreeRate)
+	assert.InDelta(t, bachelierPrice(res.Inputs, instrument.Put), res.Value(), 1e-12)
+
+	// A quoted normal volatility takes precedence
+	mkt.SetNormalVolatility("EUR6M", 0.007)
+	quoted, err := pricer.Price(context.Background(), floorlet)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.007, quoted.Inputs.Volatility)
+	assert.Greater(t, quoted.Value(), res.Value())
+
+	g, err := pricer.Greeks(context.Background(), floorlet)
+	assert.NoError(t, err)
+	assert.Less(t, g.Delta, 0.0)
+	assert.Greater(t, g.Vega, 0.0)
+
+	sigma, err := pricer.ImpliedVolatility(context.Background(), floorlet, quoted.Value())
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.007, sigma, 1e-8)
+
+	_, err = NewBachelierPricer(mkt, 0, 0.005).Price(context.Background(), instrument.NewAmericanOption("A", fra, decimal.Zero, yearsFrom(now, 1), instrument.Put))
+	assert.ErrorIs(t, err, ErrUnsupportedInstrument)
+}
+
+func TestBachelierApproximatesBlack76AtTheMoney(t *testing.T) {
+	// At the money a normal vol of sigma*F prices like a lognormal sigma
+	in := Inputs{Spot: 100, Strike: 100, Expiry: 0.5, RiskFreeRate: 0.03, Volatility: 0.2}
+	black := black76Price(in, instrument.Call)
+	normal, err := NormalImpliedVolatility(black, in, instrument.Call)
+	assert.NoError(t, err)
+	assert.InDelta(t, 20, normal, 0.05)
+}
@@ -0,0 +1,128 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+)
+
+// Black76Pricer implements the Black (1976) model for European options on
+// futures and forwards. The underlying's quote in the market snapshot is
+// its futures price F, which the model takes as lognormal and driftless;
+// premiums are discounted at the risk-free rate. RiskFreeRate and
+// Volatility are defaults used when the snapshot does not quote them.
+type Black76Pricer struct {
+	Market       *market.Snapshot
+	RiskFreeRate float64
+	Volatility   float64
+}
+
+// NewBlack76Pricer creates a new Black-76 pricer valuing against mkt.
+func NewBlack76Pricer(mkt *market.Snapshot, r, sigma float64) *Black76Pricer {
+	return &Black76Pricer{
+		Market:       mkt,
+		RiskFreeRate: r,
+		Volatility:   sigma,
+	}
+}
+
+var (
+	_ Pricer           = (*Black76Pricer)(nil)
+	_ GreeksCalculator = (*Black76Pricer)(nil)
+)
+
+// Price values a European option on a forward in closed form. The reported
+// Inputs.Spot is the forward.
+func (b *Black76Pricer) Price(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+	opt, in, err := b.resolve(ctx, inst)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	return PricingResult{
+		Price:  money.NewFromFloat(black76Price(in, opt.OptionType()), opt.Currency()),
+		Model:  ModelBlack76,
+		Inputs: in,
+	}, nil
+}
+
+// Greeks returns the analytic Black-76 sensitivities. Delta and Gamma are
+// taken with respect to the forward, and Rho holds the forward fixed, so
+// only the discounting moves.
+func (b *Black76Pricer) Greeks(ctx context.Context, inst instrument.Instrument) (Greeks, error) {
+	opt, in, err := b.resolve(ctx, inst)
+	if err != nil {
+		return Greeks{}, err
+	}
+	return black76Greeks(in, opt.OptionType()), nil
+}
+
+// ImpliedVolatility backs out the Black-76 volatility implied by premium.
+func (b *Black76Pricer) ImpliedVolatility(ctx context.Context, inst instrument.Instrument, premium float64) (float64, error) {
+	opt, in, err := b.resolve(ctx, inst)
+	if err != nil {
+		return 0, err
+	}
+	return Black76ImpliedVolatility(premium, in, opt.OptionType())
+}
+
+func (b *Black76Pricer) resolve(ctx context.Context, inst instrument.Instrument) (*instrument.Option, Inputs, error) {
+	opt, err := asEuropeanOption(inst)
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, Inputs{}, err
+	}
+	in, err := forwardInputs(b.Market, opt, b.RiskFreeRate, b.Volatility)
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	if err := in.Validate(); err != nil {
+		return nil, Inputs{}, err
+	}
+	return opt, in, nil
+}
+
+// forwardInputs resolves the inputs of an option on a forward, with the
+// forward in Spot. Dividends are already in the forward, so they are
+// dropped.
+func forwardInputs(mkt *market.Snapshot, opt *instrument.Option, r, sigma float64) (Inputs, error) {
+	in, err := marketInputs(mkt, opt, opt.Strike().InexactFloat64(), r, sigma)
+	if err != nil {
+		return Inputs{}, err
+	}
+	in.DividendYield = 0
+	in.Dividends = nil
+	return in, nil
+}
+
+// black76Inputs maps Black-76 inputs onto Black-Scholes-Merton ones with
+// a dividend yield equal to the rate, under which S e^{-qT} = F e^{-rT}.
+func black76Inputs(in Inputs) Inputs {
+	in.DividendYield = in.RiskFreeRate
+	return in
+}
+
+// black76Price returns the Black-76 value of a European option on the
+// forward in.Spot.
+func black76Price(in Inputs, optType instrument.OptionType) float64 {
+	return bsPrice(black76Inputs(in), optType)
+}
+
+// black76Greeks returns the Black-76 sensitivities of a European option.
+// They are the Black-Scholes-Merton ones with q = r, except for Rho, where
+// the forward rather than the spot stays put.
+func black76Greeks(in Inputs, optType instrument.OptionType) Greeks {
+	g := bsGreeks(black76Inputs(in), optType)
+	g.Rho = -in.Expiry * black76Price(in, optType)
+	return g
+}
+
+// Black76ImpliedVolatility returns the Black-76 volatility at which an
+// option on the forward in.Spot is worth premium, solving as
+// ImpliedVolatility does.
+func Black76ImpliedVolatility(premium float64, in Inputs, optType instrument.OptionType) (float64, error) {
+	return ImpliedVolatility(premium, black76Inputs(in), optType)
+}
@@ -0,0 +1,115 @@
+package pricing
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlack76Pricer_Textbook(t *testing.T) {
+	// Hull: futures at 20, K=20, r=9%, sigma=25%, four months. Put 1.12
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("CL", 20)
+	future := instrument.NewFuture("CLZ5", "USD", "CL", yearsFrom(now, 0.5))
+	put := instrument.NewEuropeanOption("P", future, decimal.NewFromInt(20), yearsFrom(now, 4.0/12), instrument.Put)
+
+	res, err := NewBlack76Pricer(mkt, 0.09, 0.25).Price(context.Background(), put)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.12, res.Value(), 0.005)
+	assert.Equal(t, ModelBlack76, res.Model)
+	assert.Equal(t, 20.0, res.Inputs.Spot)
+
+	// Haug: F=K=19, T=0.75, r=10%, sigma=28%. Call and put 1.7011
+	in := Inputs{Spot: 19, Strike: 19, Expiry: 0.75, RiskFreeRate: 0.10, Volatility: 0.28}
+	assert.InDelta(t, 1.7011, black76Price(in, instrument.Call), 1e-4)
+	assert.InDelta(t, 1.7011, black76Price(in, instrument.Put), 1e-4)
+}
+
+func TestBlack76Pricer_IgnoresDividends(t *testing.T) {
+	// The forward already reflects the carry of the underlying
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("ES", 5000).SetDividendYield("ES", 0.015)
+	future := instrument.NewFuture("ESZ5", "USD", "ES", yearsFrom(now, 1))
+	call := instrument.NewEuropeanOption("C", future, decimal.NewFromInt(5100), yearsFrom(now, 0.5), instrument.Call)
+	put := instrument.NewEuropeanOption("P", future, decimal.NewFromInt(5100), yearsFrom(now, 0.5), instrument.Put)
+
+	pricer := NewBlack76Pricer(mkt, 0.04, 0.18)
+	c, err := pricer.Price(context.Background(), call)
+	assert.NoError(t, err)
+	p, err := pricer.Price(context.Background(), put)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, c.Inputs.DividendYield)
+
+	// Put-call parity on the forward
+	assert.InDelta(t, math.Exp(-0.04*0.5)*(5000-5100), c.Value()-p.Value(), 1e-6)
+}
+
+func TestBlack76Greeks_MatchFiniteDifferences(t *testing.T) {
+	in := Inputs{Spot: 72, Strike: 75, Expiry: 0.6, RiskFreeRate: 0.04, Volatility: 0.35}
+	const h = 1e-4
+
+	for _, typ := range []instrument.OptionType{instrument.Call, instrument.Put} {
+		g := black76Greeks(in, typ)
+
+		bump := func(f func(*Inputs, float64)) (Inputs, Inputs) {
+			up, down := in, in
+			f(&up, h)
+			f(&down, -h)
+			return up, down
+		}
+
+		up, down := bump(func(x *Inputs, d float64) { x.Spot += d })
+		assert.InDelta(t, (black76Price(up, typ)-black76Price(down, typ))/(2*h), g.Delta, 1e-6)
+		assert.InDelta(t, (black76Greeks(up, typ).Delta-black76Greeks(down, typ).Delta)/(2*h), g.Gamma, 1e-6)
+
+		up, down = bump(func(x *Inputs, d float64) { x.Volatility += d })
+		assert.InDelta(t, (black76Price(up, typ)-black76Price(down, typ))/(2*h), g.Vega, 1e-5)
+		assert.InDelta(t, (black76Greeks(up, typ).Vega-black76Greeks(down, typ).Vega)/(2*h), g.Volga, 1e-4)
+
+		up, down = bump(func(x *Inputs, d float64) { x.RiskFreeRate += d })
+		assert.InDelta(t, (black76Price(up, typ)-black76Price(down, typ))/(2*h), g.Rho, 1e-5)
+
+		up, down = bump(func(x *Inputs, d float64) { x.Expiry += d })
+		assert.InDelta(t, -(black76Price(up, typ)-black76Price(down, typ))/(2*h), g.Theta, 1e-5)
+		assert.InDelta(t, -(black76Greeks(up, typ).Delta-black76Greeks(down, typ).Delta)/(2*h), g.Charm, 1e-5)
+	}
+}
+
+func TestBlack76Pricer_ImpliedVolatility(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("NG", 3.2)
+	future := instrument.NewFuture("NGF6", "USD", "NG", yearsFrom(now, 1))
+	pricer := NewBlack76Pricer(mkt, 0.03, 0.5)
+
+	for _, strike := range []float64{2, 3, 3.2, 4, 6} {
+		for _, typ := range []instrument.OptionType{instrument.Call, instrument.Put} {
+			opt := instrument.NewEuropeanOption("OPT", future, decimal.NewFromFloat(strike), yearsFrom(now, 0.75), typ)
+			in := Inputs{Spot: 3.2, Strike: strike, Expiry: 0.75, RiskFreeRate: 0.03, Volatility: 0.65}
+
+			sigma, err := pricer.ImpliedVolatility(context.Background(), opt, black76Price(in, typ))
+			assert.NoError(t, err)
+			assert.InDelta(t, 0.65, sigma, 1e-6, "%s K=%g", typ, strike)
+		}
+	}
+
+	// Below the discounted intrinsic value
+	deep := Inputs{Spot: 3.2, Strike: 2, Expiry: 0.75, RiskFreeRate: 0.03}
+	_, err := Black76ImpliedVolatility(1, deep, instrument.Call)
+	assert.ErrorIs(t, err, ErrArbitrageBounds)
+}
+
+func TestBlack76Pricer_RejectsNegativeForward(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("EUR6M", -0.002)
+	fra := instrument.NewFuture("EUR6M-1Y", "EUR", "EUR6M", yearsFrom(now, 1))
+	opt := instrument.NewEuropeanOption("CAPLET", fra, decimal.NewFromFloat(0.001), yearsFrom(now, 1), instrument.Call)
+
+	_, err := NewBlack76Pricer(mkt, 0, 0.3).Price(context.Background(), opt)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
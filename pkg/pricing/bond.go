@@ -0,0 +1,328 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+)
+
+const (
+	// bondYieldTolerance and bondYieldMaxIter bound the yield solver.
+	bondYieldTolerance = 1e-12
+	bondYieldMaxIter   = 200
+	// bondBump is the parallel rate shift, one basis point, behind DV01
+	// and the effective risk measures of floating-rate bonds.
+	bondBump = 1e-4
+)
+
+// BondAnalytics are the price and risk figures of a bond, with amounts in
+// its currency for its face value.
+type BondAnalytics struct {
+	DirtyPrice      float64
+	CleanPrice      float64
+	AccruedInterest float64
+	// YieldToMaturity is the yield, compounded at the coupon frequency and
+	// annually for zero-coupon bonds, that discounts the cash flows to the
+	// dirty price.
+	YieldToMaturity float64
+	// MacaulayDuration is in years, ModifiedDuration and Convexity are
+	// taken in the yield relative to the dirty price. For floating-rate
+	// bonds they are effective measures, from shifting the projection and
+	// discount rates together.
+	MacaulayDuration float64
+	ModifiedDuration float64
+	Convexity        float64
+	// DV01 is the fall in the dirty price for a one basis point rise in
+	// the yield.
+	DV01 float64
+}
+
+// BondPricer values bonds by discounting their cash flows at the
+// continuously compounded risk-free rate plus Spread, a credit spread.
+// Floating coupons fixed at or before the valuation date pay the quote of
+// the bond's index in the snapshot; later ones are projected at the
+// forward rates of the risk-free curve. RiskFreeRate is a default used
+// when the snapshot does not quote one. Cash flows fall due on coupon
+// period end dates and are timed ACT/365 from the valuation date.
+type BondPricer struct {
+	Market       *market.Snapshot
+	RiskFreeRate float64
+	Spread       float64
+}
+
+// NewBondPricer creates a new bond pricer valuing against mkt.
+func NewBondPricer(mkt *market.Snapshot, r, spread float64) *BondPricer {
+	return &BondPricer{
+		Market:       mkt,
+		RiskFreeRate: r,
+		Spread:       spread,
+	}
+}
+
+var _ Pricer = (*BondPricer)(nil)
+
+// Price returns the dirty price of a bond, with the rest of its analytics
+// in the diagnostics.
+func (bp *BondPricer) Price(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+	v, err := bp.resolve(ctx, inst)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	a, err := v.analytics()
+	if err != nil {
+		return PricingResult{}, err
+	}
+	return PricingResult{
+		Price: money.NewFromFloat(a.DirtyPrice, v.bond.Currency()),
+		Model: ModelDiscountedCashFlow,
+		Inputs: Inputs{
+			RiskFreeRate: v.rate,
+			Expiry:       yearFraction(v.settlement, v.bond.Maturity()),
+		},
+		Diagnostics: map[string]float64{
+			"clean price":       a.CleanPrice,
+			"accrued interest":  a.AccruedInterest,
+			"yield to maturity": a.YieldToMaturity,
+			"macaulay duration": a.MacaulayDuration,
+			"modified duration": a.ModifiedDuration,
+			"convexity":         a.Convexity,
+			"dv01":              a.DV01,
+		},
+	}, nil
+}
+
+// Analytics returns the price and risk figures of a bond.
+func (bp *BondPricer) Analytics(ctx context.Context, inst instrument.Instrument) (BondAnalytics, error) {
+	v, err := bp.resolve(ctx, inst)
+	if err != nil {
+		return BondAnalytics{}, err
+	}
+	return v.analytics()
+}
+
+// YieldToMaturity backs out the yield to maturity implied by a clean price
+// quoted in the bond's currency for its face value.
+func (bp *BondPricer) YieldToMaturity(ctx context.Context, inst instrument.Instrument, cleanPrice float64) (float64, error) {
+	v, err := bp.resolve(ctx, inst)
+	if err != nil {
+		return 0, err
+	}
+	if err := positive("clean price", cleanPrice); err != nil {
+		return 0, err
+	}
+	flows, accrued := v.cashFlows(v.rate)
+	return bondYield(flows, cleanPrice+accrued, v.compounding())
+}
+
+func (bp *BondPricer) resolve(ctx context.Context, inst instrument.Instrument) (bondValuation, error) {
+	b, ok := inst.(*instrument.Bond)
+	if !ok {
+		return bondValuation{}, unsupported(inst)
+	}
+	if err := ctx.Err(); err != nil {
+		return bondValuation{}, err
+	}
+	if bp.Market == nil {
+		return bondValuation{}, fmt.Errorf("%w: no snapshot to price %s", ErrMissingMarketData, b.ID())
+	}
+	v := bondValuation{
+		bond:       b,
+		settlement: valuationTime(bp.Market),
+		face:       b.Face().InexactFloat64(),
+		coupon:     b.Coupon().InexactFloat64(),
+		rate:       bp.RiskFreeRate,
+		spread:     bp.Spread,
+	}
+	if rate, ok := bp.Market.RiskFreeRate(); ok {
+		v.rate = rate
+	}
+	if err := v.validate(); err != nil {
+		return bondValuation{}, err
+	}
+
+	// The coupon running at settlement has been fixed already
+	if b.CouponType() == instrument.FloatingCoupon {
+		for _, p := range b.Schedule() {
+			if !p.Start.After(v.settlement) && p.End.After(v.settlement) {
+				fixing, ok := bp.Market.Spot(b.Index())
+				if !ok {
+					return bondValuation{}, fmt.Errorf("%w: no fixing for %s", ErrMissingMarketData, b.Index())
+				}
+				if err := finite("fixing", fixing); err != nil {
+					return bondValuation{}, err
+				}
+				v.fixing = fixing
+			}
+		}
+	}
+	return v, nil
+}
+
+// bondFlow is a cash flow of amount due in time years.
+type bondFlow struct {
+	time   float64
+	amount float64
+}
+
+// bondValuation is a bond with the market it is valued in.
+type bondValuation struct {
+	bond       *instrument.Bond
+	settlement time.Time
+	face       float64
+	coupon     float64 // fixed rate, or spread over the index
+	fixing     float64 // index rate of the floating coupon running at settlement
+	rate       float64
+	spread     float64
+}
+
+func (v bondValuation) validate() error {
+	b := v.bond
+	if err := positive("face", v.face); err != nil {
+		return err
+	}
+	if err := finite("coupon", v.coupon); err != nil {
+		return err
+	}
+	if err := finite("rate", v.rate); err != nil {
+		return err
+	}
+	if err := finite("spread", v.spread); err != nil {
+		return err
+	}
+	if life := yearFraction(b.Issue(), b.Maturity()); !(life > 0) {
+		return &InputError{Field: "maturity", Value: life}
+	}
+	if left := yearFraction(v.settlement, b.Maturity()); left <= 0 {
+		return fmt.Errorf("%w: %.6f years to maturity", ErrExpired, left)
+	}
+	if b.CouponType() != instrument.ZeroCoupon {
+		if f := b.Frequency(); f <= 0 || 12%f != 0 {
+			return &InputError{Field: "coupon frequency", Value: float64(f)}
+		}
+	}
+	return nil
+}
+
+// compounding returns the number of times a year the bond's yield
+// compounds.
+func (v bondValuation) compounding() float64 {
+	return float64(max(v.bond.Frequency(), 1))
+}
+
+// cashFlows returns the cash flows due after settlement, with floating
+// coupons projected off the risk-free rate r, and the interest accrued at
+// settlement.
+func (v bondValuation) cashFlows(r float64) ([]bondFlow, float64) {
+	b := v.bond
+	var flows []bondFlow
+	var accrued float64
+	for _, p := range b.Schedule() {
+		if !p.End.After(v.settlement) {
+			continue
+		}
+		period := b.Accrual(p, p.End)
+		rate := v.coupon
+		if b.CouponType() == instrument.FloatingCoupon {
+			if p.Start.After(v.settlement) {
+				// The simple forward rate over the period
+				growth := math.Exp(r * yearFraction(p.Start, p.End))
+				rate += (growth - 1) / period
+			} else {
+				rate += v.fixing
+			}
+		}
+		if p.Start.Before(v.settlement) {
+			accrued = v.face * rate * b.Accrual(p, v.settlement)
+		}
+		flows = append(flows, bondFlow{time: yearFraction(v.settlement, p.End), amount: v.face * rate * period})
+	}
+	flows = append(flows, bondFlow{time: yearFraction(v.settlement, b.Maturity()), amount: v.face})
+	return flows, accrued
+}
+
+// dirtyPrice returns the value of the bond's cash flows when the
+// risk-free rate is r.
+func (v bondValuation) dirtyPrice(r float64) float64 {
+	flows, _ := v.cashFlows(r)
+	var pv float64
+	for _, cf := range flows {
+		pv += cf.amount * math.Exp(-(r+v.spread)*cf.time)
+	}
+	return pv
+}
+
+func (v bondValuation) analytics() (BondAnalytics, error) {
+	flows, accrued := v.cashFlows(v.rate)
+	dirty := v.dirtyPrice(v.rate)
+	f := v.compounding()
+	y, err := bondYield(flows, dirty, f)
+	if err != nil {
+		return BondAnalytics{}, err
+	}
+	a := BondAnalytics{
+		DirtyPrice:      dirty,
+		CleanPrice:      dirty - accrued,
+		AccruedInterest: accrued,
+		YieldToMaturity: y,
+	}
+
+	if v.bond.CouponType() == instrument.FloatingCoupon {
+		// The projected coupons move with rates, so the yield measures
+		// would overstate the risk. The sensitivities to the continuous
+		// rate are turned into ones to the yield, through dr/dy = 1/(1 + y/f).
+		up, down := v.dirtyPrice(v.rate+bondBump), v.dirtyPrice(v.rate-bondBump)
+		duration := (down - up) / (2 * bondBump * dirty)
+		convexity := (up + down - 2*dirty) / (bondBump * bondBump * dirty)
+		a.MacaulayDuration = duration
+		a.ModifiedDuration = duration / (1 + y/f)
+		a.Convexity = (convexity + duration/f) / ((1 + y/f) * (1 + y/f))
+		a.DV01 = a.ModifiedDuration * dirty * bondBump
+		return a, nil
+	}
+
+	// Derivatives of the price in the yield, sum cf (1 + y/f)^(-f t)
+	var pv, mac, conv float64
+	for _, cf := range flows {
+		d := cf.amount * math.Pow(1+y/f, -f*cf.time)
+		pv += d
+		mac += cf.time * d
+		conv += cf.time * (cf.time + 1/f) * d
+	}
+	a.MacaulayDuration = mac / pv
+	a.ModifiedDuration = a.MacaulayDuration / (1 + y/f)
+	a.Convexity = conv / (pv * (1 + y/f) * (1 + y/f))
+	a.DV01 = a.ModifiedDuration * dirty * bondBump
+	return a, nil
+}
+
+// bondYield returns the yield, compounded f times a year, at which flows
+// are worth dirty.
+func bondYield(flows []bondFlow, dirty, f float64) (float64, error) {
+	objective := func(y float64) float64 {
+		var pv float64
+		for _, cf := range flows {
+			pv += cf.amount * math.Pow(1+y/f, -f*cf.time)
+		}
+		return pv - dirty
+	}
+
+	// The value falls with the yield, without bound towards -f; widen the
+	// bracket until it holds the root
+	lo, hi := -0.05, 0.2
+	for i := 0; objective(lo) < 0 && i < 60; i++ {
+		lo = (lo - f) / 2
+	}
+	for i := 0; objective(hi) > 0 && i < 60; i++ {
+		hi *= 2
+	}
+	y, err := brent(objective, lo, hi, bondYieldTolerance, bondYieldMaxIter)
+	if err != nil {
+		return 0, fmt.Errorf("yield for dirty price %g: %w", dirty, err)
+	}
+	return y, nil
+}
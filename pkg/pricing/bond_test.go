@@ -0,0 +1,141 @@
+package pricing
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBondPricer_ZeroCoupon(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	zero := instrument.NewZeroCouponBond("Z", "USD", decimal.NewFromInt(1000), now.AddDate(-1, 0, 0), yearsFrom(now, 4))
+	bp := NewBondPricer(market.NewSnapshot(now), 0.04, 0.01)
+
+	res, err := bp.Price(context.Background(), zero)
+	assert.NoError(t, err)
+	assert.Equal(t, ModelDiscountedCashFlow, res.Model)
+	assert.Equal(t, "USD", res.Price.Currency())
+	assert.InDelta(t, 1000*math.Exp(-0.05*4), res.Value(), 1e-9)
+
+	// The yield compounds annually, and the only cash flow sets the duration
+	a, err := bp.Analytics(context.Background(), zero)
+	assert.NoError(t, err)
+	y := math.Exp(0.05) - 1
+	assert.Equal(t, 0.0, a.AccruedInterest)
+	assert.InDelta(t, a.DirtyPrice, a.CleanPrice, 1e-12)
+	assert.InDelta(t, y, a.YieldToMaturity, 1e-10)
+	assert.InDelta(t, 4, a.MacaulayDuration, 1e-9)
+	assert.InDelta(t, 4/(1+y), a.ModifiedDuration, 1e-9)
+	assert.InDelta(t, 4*5/((1+y)*(1+y)), a.Convexity, 1e-8)
+	assert.InDelta(t, a.ModifiedDuration*a.DirtyPrice*1e-4, a.DV01, 1e-12)
+	assert.Equal(t, a.DV01, res.Diagnostics["dv01"])
+}
+
+func TestBondPricer_FixedRate(t *testing.T) {
+	now := time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC)
+	issue := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	bond := instrument.NewFixedRateBond("B", "USD", decimal.NewFromInt(100), decimal.NewFromFloat(0.06), 2, instrument.Thirty360, issue, issue.AddDate(10, 0, 0))
+	bp := NewBondPricer(market.NewSnapshot(now).SetRiskFreeRate(0.05), 0.03, 0.005)
+
+	a, err := bp.Analytics(context.Background(), bond)
+	assert.NoError(t, err)
+	// Three 30-day months into a half-year coupon of 3
+	assert.InDelta(t, 3*90.0/180, a.AccruedInterest, 1e-12)
+	assert.InDelta(t, a.DirtyPrice-a.AccruedInterest, a.CleanPrice, 1e-12)
+	// Above par, as the coupon beats the discount rate
+	assert.Greater(t, a.CleanPrice, 100.0)
+	assert.Less(t, a.YieldToMaturity, 0.06)
+	assert.Less(t, a.ModifiedDuration, a.MacaulayDuration)
+	assert.Less(t, a.MacaulayDuration, 10.0)
+
+	// Duration and convexity are the derivatives of the price in the yield
+	v, err := bp.resolve(context.Background(), bond)
+	assert.NoError(t, err)
+	flows, _ := v.cashFlows(v.rate)
+	atYield := func(y float64) float64 {
+		var pv float64
+		for _, cf := range flows {
+			pv += cf.amount * math.Pow(1+y/2, -2*cf.time)
+		}
+		return pv
+	}
+	const h = 1e-4
+	y, p := a.YieldToMaturity, a.DirtyPrice
+	assert.InDelta(t, p, atYield(y), 1e-9)
+	assert.InDelta(t, (atYield(y-h)-atYield(y+h))/(2*h*p), a.ModifiedDuration, 1e-5)
+	assert.InDelta(t, (atYield(y+h)+atYield(y-h)-2*p)/(h*h*p), a.Convexity, 1e-3)
+	assert.InDelta(t, (atYield(y-h)-atYield(y+h))/2, a.DV01, 1e-6)
+
+	// and the yield is recovered from the clean price
+	got, err := bp.YieldToMaturity(context.Background(), bond, a.CleanPrice)
+	assert.NoError(t, err)
+	assert.InDelta(t, y, got, 1e-10)
+	cheap, err := bp.YieldToMaturity(context.Background(), bond, 90)
+	assert.NoError(t, err)
+	assert.Greater(t, cheap, y)
+}
+
+func TestBondPricer_FloatingRate(t *testing.T) {
+	issue := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	frn := instrument.NewFloatingRateBond("F", "EUR", decimal.NewFromInt(100), "EUR6M", decimal.Zero, 2, instrument.Actual365Fixed, issue, issue.AddDate(5, 0, 0))
+	fixed := instrument.NewFixedRateBond("B", "EUR", decimal.NewFromInt(100), decimal.NewFromFloat(0.03), 2, instrument.Actual365Fixed, issue, issue.AddDate(5, 0, 0))
+	const r = 0.03
+
+	// On a reset date, fixed at the forward rate and discounted without a
+	// spread, a floater is worth par
+	first := frn.Schedule()[0]
+	tau := yearFraction(first.Start, first.End)
+	mkt := market.NewSnapshot(issue).SetSpot("EUR6M", (math.Exp(r*tau)-1)/tau)
+	bp := NewBondPricer(mkt, r, 0)
+	a, err := bp.Analytics(context.Background(), frn)
+	assert.NoError(t, err)
+	assert.InDelta(t, 100, a.DirtyPrice, 1e-9)
+	assert.InDelta(t, tau, a.MacaulayDuration, 1e-6)
+	assert.InDelta(t, tau/(1+a.YieldToMaturity/2), a.ModifiedDuration, 1e-6)
+
+	// so its rate risk is that of the coupon already fixed, far below a
+	// fixed-rate bond's
+	b, err := bp.Analytics(context.Background(), fixed)
+	assert.NoError(t, err)
+	assert.Less(t, 5*a.DV01, b.DV01)
+	assert.Greater(t, a.Convexity, 0.0)
+
+	// A spread is paid on top of the index
+	spread := instrument.NewFloatingRateBond("F", "EUR", decimal.NewFromInt(100), "EUR6M", decimal.NewFromFloat(0.01), 2, instrument.Actual365Fixed, issue, issue.AddDate(5, 0, 0))
+	s, err := bp.Analytics(context.Background(), spread)
+	assert.NoError(t, err)
+	assert.Greater(t, s.DirtyPrice, a.DirtyPrice)
+
+	// Between resets the running coupon needs its fixing
+	_, err = NewBondPricer(market.NewSnapshot(issue.AddDate(0, 2, 0)), r, 0).Price(context.Background(), frn)
+	assert.ErrorIs(t, err, ErrMissingMarketData)
+}
+
+func TestBondPricer_Errors(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	bp := NewBondPricer(market.NewSnapshot(now), 0.04, 0)
+
+	matured := instrument.NewZeroCouponBond("Z", "USD", decimal.NewFromInt(100), now.AddDate(-2, 0, 0), now)
+	_, err := bp.Price(context.Background(), matured)
+	assert.ErrorIs(t, err, ErrExpired)
+
+	odd := instrument.NewFixedRateBond("B", "USD", decimal.NewFromInt(100), decimal.NewFromFloat(0.05), 5, instrument.Thirty360, now, now.AddDate(2, 0, 0))
+	_, err = bp.Price(context.Background(), odd)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	noFace := instrument.NewZeroCouponBond("Z", "USD", decimal.Zero, now, now.AddDate(2, 0, 0))
+	_, err = bp.Price(context.Background(), noFace)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = bp.YieldToMaturity(context.Background(), odd, -1)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = bp.Price(context.Background(), instrument.NewEquity("E", "USD", "E"))
+	assert.ErrorIs(t, err, ErrUnsupportedInstrument)
+}
@@ -0,0 +1,86 @@
+package pricing
+
+import "math"
+
+// brownianBridge builds a Brownian path on a time grid from independent
+// normals, fixing the terminal value first and then recursively bisecting.
+// With low-discrepancy draws this puts the best-distributed dimensions on
+// the coarse shape of the path, which dominates most payoffs.
+type brownianBridge struct {
+	bridge      []int // time index set by the i-th normal
+	left        []int // index of the left neighbour, -1 for t=0
+	right       []int // index of the right neighbour
+	leftWeight  []float64
+	rightWeight []float64
+	stdDev      []float64
+}
+
+// newBrownianBridge prepares the construction order for the increasing
+// times, all after zero.
+func newBrownianBridge(times []float64) *brownianBridge {
+	n := len(times)
+	b := &brownianBridge{
+		bridge:      make([]int, n),
+		left:        make([]int, n),
+		right:       make([]int, n),
+		leftWeight:  make([]float64, n),
+		rightWeight: make([]float64, n),
+		stdDev:      make([]float64, n),
+	}
+	if n == 0 {
+		return b
+	}
+
+	used := make([]bool, n)
+	used[n-1] = true
+	b.bridge[0] = n - 1
+	b.left[0] = -1
+	b.stdDev[0] = math.Sqrt(times[n-1])
+
+	j := 0
+	for i := 1; i < n; i++ {
+		// Next gap [j, k) between populated points, filled at its midpoint
+		for used[j] {
+			j++
+		}
+		k := j
+		for !used[k] {
+			k++
+		}
+		l := j + (k-1-j)/2
+		used[l] = true
+
+		tl := 0.0
+		if j > 0 {
+			tl = times[j-1]
+		}
+		tm, tr := times[l], times[k]
+		b.bridge[i] = l
+		b.left[i] = j - 1
+		b.right[i] = k
+		b.leftWeight[i] = (tr - tm) / (tr - tl)
+		b.rightWeight[i] = (tm - tl) / (tr - tl)
+		b.stdDev[i] = math.Sqrt((tm - tl) * (tr - tm) / (tr - tl))
+
+		j = k + 1
+		if j >= n {
+			j = 0
+		}
+	}
+	return b
+}
+
+// path fills w with the Brownian motion at each time driven by z.
+func (b *brownianBridge) path(z, w []float64) {
+	if len(z) == 0 {
+		return
+	}
+	w[b.bridge[0]] = b.stdDev[0] * z[0]
+	for i := 1; i < len(z); i++ {
+		wl := 0.0
+		if b.left[i] >= 0 {
+			wl = w[b.left[i]]
+		}
+		w[b.bridge[i]] = b.leftWeight[i]*wl + b.rightWeight[i]*w[b.right[i]] + b.stdDev[i]*z[i]
+	}
+}
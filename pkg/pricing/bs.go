@@ -1,50 +1,152 @@
 package pricing
 
 import (
+    "context"
     "math"
-    "time"
 
     "github.com/antigravity/go-finance-sdk/pkg/instrument"
+    "github.com/antigravity/go-finance-sdk/pkg/market"
+    "github.com/antigravity/go-finance-sdk/pkg/money"
 )
 
 // BlackScholesPricer implements the Black-Scholes pricing model.
+// RiskFreeRate and Volatility are defaults used when the market snapshot
+// does not quote them.
 type BlackScholesPricer struct {
+    Market       *market.Snapshot
     RiskFreeRate float64
     Volatility   float64
 }
 
-// NewBlackScholesPricer creates a new Black-Scholes pricer.
-func NewBlackScholesPricer(r, sigma float64) *BlackScholesPricer {
+// NewBlackScholesPricer creates a new Black-Scholes pricer valuing against mkt.
+func NewBlackScholesPricer(mkt *market.Snapshot, r, sigma float64) *BlackScholesPricer {
     return &BlackScholesPricer{
+        Market:       mkt,
         RiskFreeRate: r,
         Volatility:   sigma,
     }
 }
 
-func (bs *BlackScholesPricer) Price(inst instrument.Instrument) (float64, error) {
-    opt, ok := inst.(*instrument.Option)
-    if !ok {
-        // Simple stub: only options supported for this example logic
-        return 0, nil
+// Price values a European option in closed form. Barrier, geometric Asian,
+// lookback and digital options are valued with their closed forms too.
+func (bs *BlackScholesPricer) Price(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+    if exoticTerms(inst) != nil {
+        return bs.priceExotic(ctx, inst)
     }
+    opt, err := asEuropeanOption(inst)
+    if err != nil {
+        return PricingResult{}, err
+    }
+    if err := ctx.Err(); err != nil {
+        return PricingResult{}, err
+    }
+
+    in, err := resolveInputs(bs.Market, opt, bs.RiskFreeRate, bs.Volatility)
+    if err != nil {
+        return PricingResult{}, err
+    }
+    return PricingResult{
+        Price:  money.NewFromFloat(bsPrice(in, opt.OptionType()), opt.Currency()),
+        Model:  ModelBlackScholes,
+        Inputs: in,
+    }, nil
+}
+
+// Greeks returns the analytic Black-Scholes-Merton sensitivities of a
+// European option, or bump-and-revalue sensitivities of an exotic one or
+// of an option on an underlying paying discrete dividends.
+func (bs *BlackScholesPricer) Greeks(ctx context.Context, inst instrument.Instrument) (Greeks, error) {
+    if exoticTerms(inst) != nil {
+        return bs.exoticGreeks(ctx, inst)
+    }
+    opt, err := asEuropeanOption(inst)
+    if err != nil {
+        return Greeks{}, err
+    }
+    if err := ctx.Err(); err != nil {
+        return Greeks{}, err
+    }
+
+    in, err := resolveInputs(bs.Market, opt, bs.RiskFreeRate, bs.Volatility)
+    if err != nil {
+        return Greeks{}, err
+    }
+    if len(in.Dividends) > 0 {
+        // The escrowed spot moves with the rate and time as well as the spot
+        return BumpGreeks(in, DefaultBumpSizes, func(x Inputs) (float64, error) {
+            return bsPrice(x, opt.OptionType()), x.Validate()
+        })
+    }
+    return bsGreeks(in, opt.OptionType()), nil
+}
 
-    S := 100.0 // Placeholder spot price since we don't have market data feed yet
-    K, _ := opt.Strike().Float64()
-    T := time.Until(opt.Expiry()).Hours() / (24 * 365)
-    r := bs.RiskFreeRate
-    sigma := bs.Volatility
+// bsD1D2 returns the d1 and d2 terms of the Black-Scholes-Merton formula.
+func bsD1D2(in Inputs) (float64, float64) {
+    S, K, T := in.Spot, in.Strike, in.Expiry
+    r, q, sigma := in.RiskFreeRate, in.DividendYield, in.Volatility
 
-    d1 := (math.Log(S/K) + (r+sigma*sigma/2.0)*T) / (sigma * math.Sqrt(T))
+    d1 := (math.Log(S/K) + (r-q+sigma*sigma/2.0)*T) / (sigma * math.Sqrt(T))
     d2 := d1 - sigma*math.Sqrt(T)
+    return d1, d2
+}
+
+// bsPrice returns the Black-Scholes-Merton value of a European option.
+// Discrete dividends are handled by the escrowed dividend model.
+func bsPrice(in Inputs, optType instrument.OptionType) float64 {
+    in = in.escrowed()
+    S, K, T := in.Spot, in.Strike, in.Expiry
+    r, q := in.RiskFreeRate, in.DividendYield
+    d1, d2 := bsD1D2(in)
+
+    if optType == instrument.Call {
+        return S*math.Exp(-q*T)*normCdf(d1) - K*math.Exp(-r*T)*normCdf(d2)
+    }
+    // Put
+    return K*math.Exp(-r*T)*normCdf(-d2) - S*math.Exp(-q*T)*normCdf(-d1)
+}
 
-    if opt.OptionType() == instrument.Call {
-        return S*normCdf(d1) - K*math.Exp(-r*T)*normCdf(d2), nil
+// bsGreeks returns the Black-Scholes-Merton sensitivities of a European option.
+func bsGreeks(in Inputs, optType instrument.OptionType) Greeks {
+    S, K, T := in.Spot, in.Strike, in.Expiry
+    r, q, sigma := in.RiskFreeRate, in.DividendYield, in.Volatility
+    d1, d2 := bsD1D2(in)
+
+    sqrtT := math.Sqrt(T)
+    dq := math.Exp(-q * T)
+    dr := math.Exp(-r * T)
+    pdf := normPdf(d1)
+
+    // Terms shared by calls and puts
+    g := Greeks{
+        Gamma: dq * pdf / (S * sigma * sqrtT),
+        Vega:  S * dq * pdf * sqrtT,
+        Vanna: -dq * pdf * d2 / sigma,
+    }
+    g.Volga = g.Vega * d1 * d2 / sigma
+    decay := -S * dq * pdf * sigma / (2 * sqrtT)
+    charmDecay := dq * pdf * (2*(r-q)*T - d2*sigma*sqrtT) / (2 * T * sigma * sqrtT)
+
+    if optType == instrument.Call {
+        g.Delta = dq * normCdf(d1)
+        g.Theta = decay - r*K*dr*normCdf(d2) + q*S*dq*normCdf(d1)
+        g.Rho = K * T * dr * normCdf(d2)
+        g.Charm = q*dq*normCdf(d1) - charmDecay
+        return g
     }
     // Put
-    return K*math.Exp(-r*T)*normCdf(-d2) - S*normCdf(-d1), nil
+    g.Delta = -dq * normCdf(-d1)
+    g.Theta = decay + r*K*dr*normCdf(-d2) - q*S*dq*normCdf(-d1)
+    g.Rho = -K * T * dr * normCdf(-d2)
+    g.Charm = -q*dq*normCdf(-d1) - charmDecay
+    return g
 }
 
 // Standard normal cumulative distribution function
 func normCdf(x float64) float64 {
     return 0.5 * (1 + math.Erf(x/math.Sqrt2))
 }
+
+// Standard normal probability density function
+func normPdf(x float64) float64 {
+    return math.Exp(-0.5*x*x) / math.Sqrt(2*math.Pi)
+}
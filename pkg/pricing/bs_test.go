@@ -1,31 +1,38 @@
 package pricing
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestBlackScholesPricer_Call(t *testing.T) {
-	// S=100 (hardcoded in bs.go), K=100, T=1 year, r=0.05, sigma=0.2
+	// S=100, K=100, T=1 year, r=0.05, sigma=0.2
 	// Expected Call Price ~ 10.4506
 
 	r := 0.05
 	sigma := 0.2
-	pricer := NewBlackScholesPricer(r, sigma)
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100)
+	pricer := NewBlackScholesPricer(mkt, r, sigma)
 
 	underlying := instrument.NewEquity("TEST", "USD", "TEST")
-	expiry := time.Now().Add(365 * 24 * time.Hour)
+	expiry := now.Add(365 * 24 * time.Hour)
 	strike := decimal.NewFromInt(100)
 
 	opt := instrument.NewEuropeanOption("OPT", underlying, strike, expiry, instrument.Call)
 
-	price, err := pricer.Price(opt)
+	res, err := pricer.Price(context.Background(), opt)
 	assert.NoError(t, err)
-	assert.InDelta(t, 10.45, price, 0.1)
+	assert.InDelta(t, 10.45, res.Value(), 0.1)
+	assert.Equal(t, ModelBlackScholes, res.Model)
+	assert.Equal(t, "USD", res.Price.Currency())
+	assert.Equal(t, 100.0, res.Inputs.Spot)
 }
 
 func TestBlackScholesPricer_Put(t *testing.T) {
@@ -34,15 +41,123 @@ func TestBlackScholesPricer_Put(t *testing.T) {
 
 	r := 0.05
 	sigma := 0.2
-	pricer := NewBlackScholesPricer(r, sigma)
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100)
+	pricer := NewBlackScholesPricer(mkt, r, sigma)
 
 	underlying := instrument.NewEquity("TEST", "USD", "TEST")
-	expiry := time.Now().Add(365 * 24 * time.Hour)
+	expiry := now.Add(365 * 24 * time.Hour)
 	strike := decimal.NewFromInt(100)
 
 	opt := instrument.NewEuropeanOption("OPT", underlying, strike, expiry, instrument.Put)
 
-	price, err := pricer.Price(opt)
+	res, err := pricer.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5.57, res.Value(), 0.1)
+}
+
+func TestBlackScholesPricer_UsesMarketData(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).
+		SetSpot("AAPL", 110).
+		SetRiskFreeRate(0.05).
+		SetVolatility("AAPL", 0.2)
+	// Pricer defaults are overridden by the snapshot
+	pricer := NewBlackScholesPricer(mkt, 0.01, 0.5)
+
+	underlying := instrument.NewEquity("AAPL-US", "USD", "AAPL")
+	expiry := now.Add(365 * 24 * time.Hour)
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), expiry, instrument.Call)
+
+	// S=110, K=100, T=1, r=0.05, sigma=0.2 => ~17.66
+	res, err := pricer.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.InDelta(t, 17.66, res.Value(), 0.01)
+	assert.Equal(t, 0.2, res.Inputs.Volatility)
+
+	// A continuous dividend yield lowers the call value
+	mkt.SetDividendYield("AAPL", 0.03)
+	withDiv, err := pricer.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.Less(t, withDiv.Value(), res.Value())
+}
+
+func TestBlackScholesPricer_MissingSpot(t *testing.T) {
+	pricer := NewBlackScholesPricer(market.NewSnapshot(time.Now()), 0.05, 0.2)
+
+	underlying := instrument.NewEquity("MSFT-US", "USD", "MSFT")
+	expiry := time.Now().Add(365 * 24 * time.Hour)
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), expiry, instrument.Call)
+
+	_, err := pricer.Price(context.Background(), opt)
+	assert.Error(t, err)
+}
+
+// yearsFrom returns the instant that is the given ACT/365 year fraction after t.
+func yearsFrom(t time.Time, years float64) time.Time {
+	return t.Add(time.Duration(years * 365 * 24 * float64(time.Hour)))
+}
+
+func TestBlackScholesPricer_GreeksTextbook(t *testing.T) {
+	// Hull, Options, Futures and Other Derivatives: S=49, K=50, r=5%,
+	// sigma=20%, T=20 weeks. Call delta 0.522, gamma 0.066, vega 12.1,
+	// theta -4.31 per year, rho 8.91.
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 49)
+	pricer := NewBlackScholesPricer(mkt, 0.05, 0.2)
+
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	expiry := yearsFrom(now, 20.0/52.0)
+	call := instrument.NewEuropeanOption("C", underlying, decimal.NewFromInt(50), expiry, instrument.Call)
+	put := instrument.NewEuropeanOption("P", underlying, decimal.NewFromInt(50), expiry, instrument.Put)
+
+	g, err := pricer.Greeks(context.Background(), call)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.522, g.Delta, 0.001)
+	assert.InDelta(t, 0.066, g.Gamma, 0.001)
+	assert.InDelta(t, 12.1, g.Vega, 0.05)
+	assert.InDelta(t, -4.31, g.Theta, 0.01)
+	assert.InDelta(t, 8.91, g.Rho, 0.01)
+
+	// Put-call parity: delta differs by one, gamma and vega agree
+	p, err := pricer.Greeks(context.Background(), put)
 	assert.NoError(t, err)
-	assert.InDelta(t, 5.57, price, 0.1)
+	assert.InDelta(t, g.Delta-1, p.Delta, 1e-9)
+	assert.InDelta(t, g.Gamma, p.Gamma, 1e-9)
+	assert.InDelta(t, g.Vega, p.Vega, 1e-9)
+	assert.InDelta(t, g.Vanna, p.Vanna, 1e-9)
+}
+
+func TestBlackScholesGreeks_MatchFiniteDifferences(t *testing.T) {
+	in := Inputs{Spot: 105, Strike: 100, Expiry: 0.75, RiskFreeRate: 0.03, DividendYield: 0.02, Volatility: 0.25}
+	const h = 1e-4
+
+	for _, typ := range []instrument.OptionType{instrument.Call, instrument.Put} {
+		g := bsGreeks(in, typ)
+
+		bump := func(f func(*Inputs, float64)) (Inputs, Inputs) {
+			up, down := in, in
+			f(&up, h)
+			f(&down, -h)
+			return up, down
+		}
+
+		up, down := bump(func(x *Inputs, d float64) { x.Spot += d })
+		assert.InDelta(t, (bsPrice(up, typ)-bsPrice(down, typ))/(2*h), g.Delta, 1e-6)
+		assert.InDelta(t, (bsGreeks(up, typ).Delta-bsGreeks(down, typ).Delta)/(2*h), g.Gamma, 1e-6)
+
+		up, down = bump(func(x *Inputs, d float64) { x.Volatility += d })
+		assert.InDelta(t, (bsPrice(up, typ)-bsPrice(down, typ))/(2*h), g.Vega, 1e-5)
+		assert.InDelta(t, (bsGreeks(up, typ).Delta-bsGreeks(down, typ).Delta)/(2*h), g.Vanna, 1e-5)
+		assert.InDelta(t, (bsGreeks(up, typ).Vega-bsGreeks(down, typ).Vega)/(2*h), g.Volga, 1e-4)
+
+		up, down = bump(func(x *Inputs, d float64) { x.RiskFreeRate += d })
+		assert.InDelta(t, (bsPrice(up, typ)-bsPrice(down, typ))/(2*h), g.Rho, 1e-5)
+
+		// Theta and charm are sensitivities to calendar time, i.e. minus
+		// the sensitivity to time to expiry.
+		up, down = bump(func(x *Inputs, d float64) { x.Expiry += d })
+		assert.InDelta(t, -(bsPrice(up, typ)-bsPrice(down, typ))/(2*h), g.Theta, 1e-5)
+		assert.InDelta(t, -(bsGreeks(up, typ).Delta-bsGreeks(down, typ).Delta)/(2*h), g.Charm, 1e-5)
+	}
 }
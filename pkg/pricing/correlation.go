@@ -0,0 +1,164 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+)
+
+// validateCorrelation checks that c is a square, symmetric matrix with a
+// unit diagonal and entries in [-1, 1]. It need not be positive
+// semidefinite.
+func validateCorrelation(c [][]float64) error {
+	n := len(c)
+	if n == 0 {
+		return fmt.Errorf("%w: empty correlation matrix", ErrInvalidInput)
+	}
+	for i, row := range c {
+		if len(row) != n {
+			return fmt.Errorf("%w: correlation matrix is not square", ErrInvalidInput)
+		}
+		if row[i] != 1 {
+			return &InputError{Field: "correlation", Value: row[i]}
+		}
+		for j, rho := range row {
+			if math.IsNaN(rho) || rho < -1 || rho > 1 {
+				return &InputError{Field: "correlation", Value: rho}
+			}
+			if math.Abs(rho-c[j][i]) > 1e-12 {
+				return fmt.Errorf("%w: correlation matrix is not symmetric at (%d, %d)", ErrInvalidInput, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// NearestCorrelation returns the correlation matrix closest to c in the
+// Frobenius norm, using the alternating projections of Higham (2002).
+// It repairs user or historically estimated matrices that are not
+// positive semidefinite; a valid correlation matrix is returned unchanged.
+func NearestCorrelation(c [][]float64) ([][]float64, error) {
+	if err := validateCorrelation(c); err != nil {
+		return nil, err
+	}
+	n := len(c)
+	if _, err := cholesky(c, 1e-12); err == nil {
+		return cloneMatrix(c), nil
+	}
+
+	y := cloneMatrix(c)
+	correction := make([][]float64, n)
+	for i := range correction {
+		correction[i] = make([]float64, n)
+	}
+	var x [][]float64
+	for iter := 0; iter < 1000; iter++ {
+		// Project onto the semidefinite cone, with Dykstra's correction
+		r := cloneMatrix(y)
+		for i := range r {
+			for j := range r[i] {
+				r[i][j] -= correction[i][j]
+			}
+		}
+		x = projectPSD(r)
+		for i := range x {
+			for j := range x[i] {
+				correction[i][j] = x[i][j] - r[i][j]
+			}
+		}
+
+		// Project onto unit-diagonal matrices
+		change := 0.0
+		for i := range x {
+			for j := range x[i] {
+				next := x[i][j]
+				if i == j {
+					next = 1
+				}
+				change += (next - y[i][j]) * (next - y[i][j])
+				y[i][j] = next
+			}
+		}
+		if math.Sqrt(change) < 1e-12*float64(n) {
+			break
+		}
+	}
+
+	// Round-off can leave the last iterate marginally indefinite; the PSD
+	// projection rescaled to a unit diagonal is a correlation matrix
+	x = projectPSD(y)
+	for i := range x {
+		for j := range x[i] {
+			if i != j {
+				x[i][j] /= math.Sqrt(x[i][i] * x[j][j])
+			}
+		}
+	}
+	for i := range x {
+		x[i][i] = 1
+	}
+	if _, err := cholesky(x, 1e-10); err != nil {
+		return nil, fmt.Errorf("%w: correlation repair did not converge", ErrNoConvergence)
+	}
+	return x, nil
+}
+
+// correlationFactor returns a lower-triangular factor of c, repairing c
+// first when it is not positive semidefinite, and the Frobenius distance
+// the repair moved it.
+func correlationFactor(c [][]float64) ([][]float64, float64, error) {
+	if err := validateCorrelation(c); err != nil {
+		return nil, 0, err
+	}
+	if L, err := cholesky(c, 1e-12); err == nil {
+		return L, 0, nil
+	}
+	repaired, err := NearestCorrelation(c)
+	if err != nil {
+		return nil, 0, err
+	}
+	L, err := cholesky(repaired, 1e-10)
+	if err != nil {
+		return nil, 0, err
+	}
+	distance := 0.0
+	for i := range c {
+		for j := range c[i] {
+			distance += (c[i][j] - repaired[i][j]) * (c[i][j] - repaired[i][j])
+		}
+	}
+	return L, math.Sqrt(distance), nil
+}
+
+// projectPSD returns the nearest positive semidefinite matrix to the
+// symmetric a, clipping its negative eigenvalues to zero.
+func projectPSD(a [][]float64) [][]float64 {
+	values, V := symmetricEigen(a)
+	n := len(a)
+	out := make([][]float64, n)
+	for i := range out {
+		out[i] = make([]float64, n)
+		for j := 0; j <= i; j++ {
+			s := 0.0
+			for k, lambda := range values {
+				if lambda > 0 {
+					s += V[i][k] * lambda * V[j][k]
+				}
+			}
+			out[i][j] = s
+		}
+	}
+	for i := range out {
+		for j := i + 1; j < n; j++ {
+			out[i][j] = out[j][i]
+		}
+	}
+	return out
+}
+
+func cloneMatrix(a [][]float64) [][]float64 {
+	out := make([][]float64, len(a))
+	for i := range a {
+		out[i] = append([]float64(nil), a[i]...)
+	}
+	return out
+}
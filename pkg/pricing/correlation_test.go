@@ -0,0 +1,102 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCholesky(t *testing.T) {
+	A := [][]float64{{4, 2, 0.4}, {2, 2, 0.7}, {0.4, 0.7, 3}}
+	L, err := cholesky(A, 1e-12)
+	assert.NoError(t, err)
+	for i := range A {
+		for j := range A {
+			s := 0.0
+			for k := range A {
+				s += L[i][k] * L[j][k]
+			}
+			assert.InDelta(t, A[i][j], s, 1e-12)
+		}
+		for j := i + 1; j < len(A); j++ {
+			assert.Equal(t, 0.0, L[i][j])
+		}
+	}
+
+	// Perfectly correlated assets give a singular but valid factor
+	L, err = cholesky([][]float64{{1, 1}, {1, 1}}, 1e-12)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{1, 0}, {1, 0}}, L)
+
+	_, err = cholesky([][]float64{{1, 0.9, 0.7}, {0.9, 1, -0.4}, {0.7, -0.4, 1}}, 1e-12)
+	assert.True(t, errors.Is(err, errNotPSD))
+}
+
+func TestSymmetricEigen(t *testing.T) {
+	A := [][]float64{{2, -1, 0}, {-1, 2, -1}, {0, -1, 2}}
+	values, V := symmetricEigen(A)
+	for k, lambda := range values {
+		for i := range A {
+			av := 0.0
+			for j := range A {
+				av += A[i][j] * V[j][k]
+			}
+			assert.InDelta(t, lambda*V[i][k], av, 1e-12)
+		}
+	}
+	// Eigenvalues 2 - sqrt(2), 2, 2 + sqrt(2) in some order
+	assert.InDelta(t, 6, values[0]+values[1]+values[2], 1e-12)
+	assert.InDelta(t, 4, values[0]*values[1]*values[2], 1e-12)
+}
+
+func TestNearestCorrelation_Higham(t *testing.T) {
+	// Higham (2002), section 4
+	c := [][]float64{{1, 1, 0}, {1, 1, 1}, {0, 1, 1}}
+	x, err := NearestCorrelation(c)
+	assert.NoError(t, err)
+	want := [][]float64{{1, 0.7607, 0.1573}, {0.7607, 1, 0.7607}, {0.1573, 0.7607, 1}}
+	for i := range want {
+		for j := range want {
+			assert.InDelta(t, want[i][j], x[i][j], 1e-4, "(%d, %d)", i, j)
+		}
+	}
+	// The input is untouched
+	assert.Equal(t, 0.0, c[0][2])
+
+	// A valid matrix is already nearest
+	valid := [][]float64{{1, 0.5}, {0.5, 1}}
+	x, err = NearestCorrelation(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, valid, x)
+}
+
+func TestCorrelationFactor(t *testing.T) {
+	c := [][]float64{{1, 0.9, 0.7}, {0.9, 1, -0.4}, {0.7, -0.4, 1}}
+	L, distance, err := correlationFactor(c)
+	assert.NoError(t, err)
+	assert.Greater(t, distance, 0.0)
+	for i := range c {
+		norm := 0.0
+		for k := range c {
+			norm += L[i][k] * L[i][k]
+		}
+		assert.InDelta(t, 1, math.Sqrt(norm), 1e-9)
+	}
+
+	_, distance, err = correlationFactor([][]float64{{1, -0.3}, {-0.3, 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, distance)
+
+	for _, bad := range [][][]float64{
+		{},
+		{{1, 0.5}, {0.4, 1}},
+		{{1, 1.5}, {1.5, 1}},
+		{{0.9, 0.5}, {0.5, 1}},
+		{{1, 0.5}},
+	} {
+		_, _, err := correlationFactor(bad)
+		assert.True(t, errors.Is(err, ErrInvalidInput), "%v", bad)
+	}
+}
@@ -0,0 +1,113 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+)
+
+// Dividend is a discrete dividend going ex Time years after valuation.
+// Amount is paid per share, or as a fraction of the spot when Proportional.
+type Dividend struct {
+	Time         float64
+	Amount       float64
+	Proportional bool
+}
+
+// dividendPayer is an underlying with a discrete dividend schedule.
+type dividendPayer interface {
+	Dividends() []instrument.Dividend
+}
+
+// dividendSchedule returns the dividends of underlying going ex after asOf
+// and no later than expiry, in years from asOf.
+func dividendSchedule(underlying instrument.Instrument, asOf, expiry time.Time) []Dividend {
+	payer, ok := underlying.(dividendPayer)
+	if !ok {
+		return nil
+	}
+	var divs []Dividend
+	for _, d := range payer.Dividends() {
+		if !d.ExDate.After(asOf) || d.ExDate.After(expiry) {
+			continue
+		}
+		divs = append(divs, Dividend{
+			Time:         yearFraction(asOf, d.ExDate),
+			Amount:       d.Amount.InexactFloat64(),
+			Proportional: d.Kind == instrument.ProportionalDividend,
+		})
+	}
+	return divs
+}
+
+// validateDividends checks cash amounts are not negative, proportional
+// ones lie in [0, 1), and the dividends leave a positive escrowed spot.
+func (in Inputs) validateDividends() error {
+	for _, d := range in.Dividends {
+		if err := finite("dividend time", d.Time); err != nil {
+			return err
+		}
+		if d.Amount < 0 || math.IsNaN(d.Amount) || math.IsInf(d.Amount, 0) || (d.Proportional && d.Amount >= 1) {
+			return &InputError{Field: "dividend", Value: d.Amount}
+		}
+	}
+	if len(in.Dividends) > 0 {
+		if x := in.escrowed().Spot; x <= 0 {
+			return fmt.Errorf("%w: dividends worth more than the spot %g", ErrInvalidInput, in.Spot)
+		}
+	}
+	return nil
+}
+
+// dividendAdjustment returns the present value at time t of the cash
+// dividends going ex after t, and the fraction of the spot kept after the
+// proportional ones. The spot at t is then (x + cash) / factor, where x is
+// the escrowed spot.
+func (in Inputs) dividendAdjustment(t float64) (cash, factor float64) {
+	factor = 1
+	for _, d := range in.Dividends {
+		if d.Time <= t {
+			continue
+		}
+		if d.Proportional {
+			factor *= 1 - d.Amount
+		} else {
+			cash += d.Amount * math.Exp(-in.RiskFreeRate*(d.Time-t))
+		}
+	}
+	return cash, factor
+}
+
+// escrowed returns the inputs with the discrete dividends taken out of the
+// spot: the spot less the present value of cash dividends, scaled by the
+// proportional ones. Lognormal models then apply to the escrowed spot.
+func (in Inputs) escrowed() Inputs {
+	if len(in.Dividends) == 0 {
+		return in
+	}
+	cash, factor := in.dividendAdjustment(0)
+	x := in
+	x.Spot = in.Spot*factor - cash
+	x.Dividends = nil
+	return x
+}
+
+// elapse returns the inputs dt years later: expiry and dividend times move
+// closer, and dividends that have gone ex drop out.
+func (in Inputs) elapse(dt float64) Inputs {
+	in.Expiry -= dt
+	if len(in.Dividends) == 0 {
+		return in
+	}
+	var divs []Dividend
+	for _, d := range in.Dividends {
+		if d.Time > dt {
+			d.Time -= dt
+			divs = append(divs, d)
+		}
+	}
+	in.Dividends = divs
+	return in
+}
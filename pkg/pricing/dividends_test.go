@@ -0,0 +1,214 @@
+package pricing
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketInputs_DividendSchedule(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL").WithDividends(
+		instrument.NewCashDividend(yearsFrom(now, -0.1), decimal.NewFromInt(1)),
+		instrument.NewCashDividend(yearsFrom(now, 0.25), decimal.NewFromFloat(0.5)),
+		instrument.NewProportionalDividend(yearsFrom(now, 0.5), decimal.NewFromFloat(0.02)),
+		instrument.NewCashDividend(yearsFrom(now, 2), decimal.NewFromInt(1)),
+	)
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+
+	in, err := resolveInputs(mkt, opt, 0.05, 0.2)
+	assert.NoError(t, err)
+	if assert.Len(t, in.Dividends, 2, "only dividends going ex before expiry") {
+		assert.InDelta(t, 0.25, in.Dividends[0].Time, 1e-9)
+		assert.Equal(t, 0.5, in.Dividends[0].Amount)
+		assert.False(t, in.Dividends[0].Proportional)
+		assert.InDelta(t, 0.5, in.Dividends[1].Time, 1e-9)
+		assert.True(t, in.Dividends[1].Proportional)
+	}
+
+	escrowed := in.escrowed()
+	assert.Nil(t, escrowed.Dividends)
+	assert.InDelta(t, 100*0.98-0.5*math.Exp(-0.05*0.25), escrowed.Spot, 1e-9)
+
+	later := in.elapse(0.3)
+	assert.InDelta(t, 0.7, later.Expiry, 1e-9)
+	if assert.Len(t, later.Dividends, 1, "the first dividend has gone ex") {
+		assert.InDelta(t, 0.2, later.Dividends[0].Time, 1e-9)
+	}
+	assert.Len(t, in.Dividends, 2, "elapse does not touch the original schedule")
+}
+
+func TestInputs_ValidateDividends(t *testing.T) {
+	base := Inputs{Spot: 100, Strike: 100, Expiry: 1, RiskFreeRate: 0.05, Volatility: 0.2}
+	for name, divs := range map[string][]Dividend{
+		"negative cash":      {{Time: 0.5, Amount: -1}},
+		"whole spot":         {{Time: 0.5, Amount: 1, Proportional: true}},
+		"worth the spot":     {{Time: 0.2, Amount: 60}, {Time: 0.4, Amount: 60}},
+		"not a number":       {{Time: 0.5, Amount: math.NaN()}},
+		"infinite ex-date":   {{Time: math.Inf(1), Amount: 1}},
+		"negative fraction":  {{Time: 0.5, Amount: -0.1, Proportional: true}},
+		"infinite cash paid": {{Time: 0.5, Amount: math.Inf(1)}},
+	} {
+		in := base
+		in.Dividends = divs
+		assert.ErrorIs(t, in.Validate(), ErrInvalidInput, name)
+	}
+
+	base.Dividends = []Dividend{{Time: 0.5, Amount: 2}, {Time: 0.75, Amount: 0.03, Proportional: true}}
+	assert.NoError(t, base.Validate())
+}
+
+func TestBlackScholesPricer_CashDividends(t *testing.T) {
+	// Hull: S=40, K=40, r=9%, sigma=30%, T=6 months, dividends of 0.50 in
+	// two and five months. The escrowed spot is 39.0259 and the call 3.67
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 40)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST").WithDividends(
+		instrument.NewCashDividend(yearsFrom(now, 2.0/12), decimal.NewFromFloat(0.5)),
+		instrument.NewCashDividend(yearsFrom(now, 5.0/12), decimal.NewFromFloat(0.5)),
+	)
+	opt := instrument.NewEuropeanOption("C", underlying, decimal.NewFromInt(40), yearsFrom(now, 0.5), instrument.Call)
+
+	bs := NewBlackScholesPricer(mkt, 0.09, 0.30)
+	res, err := bs.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.InDelta(t, 3.67, res.Value(), 0.005)
+	assert.InDelta(t, 39.0259, res.Inputs.escrowed().Spot, 1e-4)
+	assert.Len(t, res.Inputs.Dividends, 2)
+
+	// A cash dividend shifts the spot one for one, so delta and gamma are
+	// those of the escrowed option
+	g, err := bs.Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+	want := bsGreeks(res.Inputs.escrowed(), instrument.Call)
+	assert.InDelta(t, want.Delta, g.Delta, 1e-4)
+	assert.InDelta(t, want.Gamma, g.Gamma, 1e-3)
+	assert.InDelta(t, want.Vega, g.Vega, 1e-2)
+
+	// The implied volatility of the price is the input volatility
+	vol, err := bs.ImpliedVolatility(context.Background(), opt, res.Value())
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.30, vol, 1e-8)
+}
+
+func TestBlackScholesPricer_ProportionalDividend(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100).SetDividendYield("TEST", 0.01)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST").WithDividends(
+		instrument.NewProportionalDividend(yearsFrom(now, 0.5), decimal.NewFromFloat(0.03)),
+	)
+	opt := instrument.NewEuropeanOption("P", underlying, decimal.NewFromInt(95), yearsFrom(now, 1), instrument.Put)
+
+	res, err := NewBlackScholesPricer(mkt, 0.04, 0.25).Price(context.Background(), opt)
+	assert.NoError(t, err)
+	want := bsPrice(Inputs{Spot: 97, Strike: 95, Expiry: 1, RiskFreeRate: 0.04, DividendYield: 0.01, Volatility: 0.25}, instrument.Put)
+	assert.InDelta(t, want, res.Value(), 1e-9)
+}
+
+func TestBlackScholesPricer_ExoticsUnderDividends(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST").WithDividends(
+		instrument.NewCashDividend(yearsFrom(now, 0.5), decimal.NewFromInt(2)),
+	)
+	expiry := yearsFrom(now, 1)
+	bs := NewBlackScholesPricer(mkt, 0.05, 0.2)
+
+	barrier := instrument.NewBarrierOption("B", underlying, decimal.NewFromInt(100), expiry, instrument.Call, instrument.UpAndOut, decimal.NewFromInt(130), decimal.Zero)
+	_, err := bs.Price(context.Background(), barrier)
+	assert.ErrorIs(t, err, ErrUnsupportedInstrument)
+
+	// Digitals only see the terminal spot, so they price on the escrowed one
+	digital := instrument.NewCashOrNothingOption("D", underlying, decimal.NewFromInt(100), expiry, instrument.Call, decimal.NewFromInt(10))
+	res, err := bs.Price(context.Background(), digital)
+	assert.NoError(t, err)
+	want := digitalPrice(res.Inputs.escrowed(), instrument.Call, instrument.CashOrNothing, 10)
+	assert.InDelta(t, want, res.Value(), 1e-9)
+	assert.Less(t, res.Value(), digitalPrice(res.Inputs, instrument.Call, instrument.CashOrNothing, 10))
+}
+
+func TestLatticePricer_AmericanCallWithDividends(t *testing.T) {
+	// A large dividend just before expiry makes exercising the call ahead
+	// of the ex-date worth more than holding it
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST").WithDividends(
+		instrument.NewCashDividend(yearsFrom(now, 0.4), decimal.NewFromInt(6)),
+	)
+	expiry := yearsFrom(now, 0.5)
+	american := instrument.NewAmericanOption("AC", underlying, decimal.NewFromInt(90), expiry, instrument.Call)
+	european := instrument.NewEuropeanOption("EC", underlying, decimal.NewFromInt(90), expiry, instrument.Call)
+
+	bsEuro, err := NewBlackScholesPricer(mkt, 0.05, 0.25).Price(context.Background(), european)
+	assert.NoError(t, err)
+
+	var binomial float64
+	for _, method := range []LatticeMethod{BinomialCRR, Trinomial} {
+		lp := NewLatticePricer(mkt, method, 800, 0.05, 0.25)
+		euro, err := lp.Price(context.Background(), european)
+		assert.NoError(t, err)
+		assert.InDelta(t, bsEuro.Value(), euro.Value(), 0.02, method)
+
+		amer, err := lp.Price(context.Background(), american)
+		assert.NoError(t, err)
+		assert.Greater(t, amer.Value(), euro.Value()+0.5, "early exercise premium, %s", method)
+		if method == BinomialCRR {
+			binomial = amer.Value()
+		} else {
+			assert.InDelta(t, binomial, amer.Value(), 0.02)
+		}
+
+		g, err := lp.Greeks(context.Background(), american)
+		assert.NoError(t, err)
+		assert.Greater(t, g.Delta, 0.0)
+		assert.Less(t, g.Delta, 1.0)
+	}
+
+	// Longstaff-Schwartz sees the same exercise opportunity
+	mc := NewMonteCarloPricer(mkt, 50000, 0.05, 0.25)
+	mc.Steps = 100
+	mc.Seed = 7
+	lsm, err := mc.Price(context.Background(), american)
+	assert.NoError(t, err)
+	assert.Equal(t, ModelLongstaffSchwartz, lsm.Model)
+	assert.InDelta(t, binomial, lsm.Value(), 3*lsm.StdErr+0.05)
+}
+
+func TestMonteCarloPricer_Dividends(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100).SetDividendYield("TEST", 0.01)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST").WithDividends(
+		instrument.NewCashDividend(yearsFrom(now, 0.3), decimal.NewFromInt(3)),
+		instrument.NewProportionalDividend(yearsFrom(now, 0.8), decimal.NewFromFloat(0.02)),
+	)
+	opt := instrument.NewEuropeanOption("C", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+
+	bs := NewBlackScholesPricer(mkt, 0.05, 0.25)
+	want, err := bs.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	wantGreeks, err := bs.Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+
+	for name, process := range map[string]ProcessFunc{"terminal": nil, "process": GBMProcess} {
+		mc := NewMonteCarloPricer(mkt, 100000, 0.05, 0.25)
+		mc.Process = process
+		mc.Steps = 20
+		mc.Seed = 11
+
+		got, err := mc.Price(context.Background(), opt)
+		assert.NoError(t, err)
+		assert.InDelta(t, want.Value(), got.Value(), 3*got.StdErr+1e-3, name)
+
+		g, err := mc.Greeks(context.Background(), opt)
+		assert.NoError(t, err)
+		assert.InDelta(t, wantGreeks.Delta, g.Delta, 0.01, name)
+		assert.InDelta(t, wantGreeks.Vega, g.Vega, 1, name)
+	}
+}
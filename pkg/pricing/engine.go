@@ -1,10 +1,49 @@
 package pricing
 
 import (
+    "context"
+
     "github.com/antigravity/go-finance-sdk/pkg/instrument"
+    "github.com/antigravity/go-finance-sdk/pkg/money"
+)
+
+// Model names reported in PricingResult.Model.
+const (
+    ModelBlackScholes = "black-scholes"
+    ModelMonteCarlo   = "monte-carlo"
+    // ModelLongstaffSchwartz is reported by MonteCarloPricer for options
+    // with early exercise.
+    ModelLongstaffSchwartz  = "longstaff-schwartz"
+    ModelHeston             = "heston"
+    ModelBlack76            = "black-76"
+    ModelBachelier          = "bachelier"
+    ModelFiniteDifference   = "finite-difference"
+    ModelDiscountedCashFlow = "discounted-cash-flow"
 )
 
 // Pricer interface for pricing instruments.
+// Every model in this package implements it, so callers can swap models freely.
 type Pricer interface {
-    Price(inst instrument.Instrument) (float64, error)
+    Price(ctx context.Context, inst instrument.Instrument) (PricingResult, error)
+}
+
+// PricingResult is the outcome of valuing an instrument with a Pricer.
+type PricingResult struct {
+    Price  money.Money
+    Model  string
+    Inputs Inputs
+    // StdErr is the standard error of a simulated price; zero for closed forms.
+    StdErr float64
+    // Diagnostics carries model-specific figures such as path or step counts.
+    Diagnostics map[string]float64
 }
+
+// Value returns the price as a float64.
+func (r PricingResult) Value() float64 {
+    return r.Price.Amount().InexactFloat64()
+}
+
+var (
+    _ Pricer = (*BlackScholesPricer)(nil)
+    _ Pricer = (*MonteCarloPricer)(nil)
+)
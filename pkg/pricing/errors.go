@@ -0,0 +1,82 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+)
+
+var (
+	// ErrUnsupportedInstrument is returned when a pricer cannot value the
+	// instrument it was given.
+	ErrUnsupportedInstrument = errors.New("pricing: unsupported instrument")
+	// ErrExpired is returned when the contract expires at or before the
+	// valuation time.
+	ErrExpired = errors.New("pricing: contract has expired")
+	// ErrInvalidInput is returned when a model input is outside its domain.
+	// Use errors.As with *InputError to find the offending field.
+	ErrInvalidInput = errors.New("pricing: invalid input")
+	// ErrMissingMarketData is returned when the market snapshot lacks data
+	// required to value the instrument.
+	ErrMissingMarketData = errors.New("pricing: missing market data")
+)
+
+// InputError describes a model input that failed validation.
+type InputError struct {
+	Field string
+	Value float64
+}
+
+func (e *InputError) Error() string {
+	return fmt.Sprintf("pricing: invalid input %s=%g", e.Field, e.Value)
+}
+
+// Unwrap lets errors.Is match ErrInvalidInput.
+func (e *InputError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// unsupported builds an ErrUnsupportedInstrument error naming inst.
+func unsupported(inst instrument.Instrument) error {
+	return fmt.Errorf("%w: %s %s", ErrUnsupportedInstrument, inst.Type(), inst.ID())
+}
+
+// asOption narrows inst to an option or reports it as unsupported.
+func asOption(inst instrument.Instrument) (*instrument.Option, error) {
+	opt, ok := inst.(*instrument.Option)
+	if !ok {
+		return nil, unsupported(inst)
+	}
+	return opt, nil
+}
+
+// asEuropeanOption narrows inst to a European option, reporting other
+// instruments and exercise styles as unsupported.
+func asEuropeanOption(inst instrument.Instrument) (*instrument.Option, error) {
+	opt, err := asOption(inst)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Style() != instrument.European {
+		return nil, fmt.Errorf("%w: %s exercise of %s", ErrUnsupportedInstrument, opt.Style(), opt.ID())
+	}
+	return opt, nil
+}
+
+// positive fails unless v is a finite number greater than zero.
+func positive(field string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) || v <= 0 {
+		return &InputError{Field: field, Value: v}
+	}
+	return nil
+}
+
+// finite fails if v is NaN or infinite.
+func finite(field string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return &InputError{Field: field, Value: v}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricersRejectBadInputs(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	nextYear := now.Add(365 * 24 * time.Hour)
+
+	tests := []struct {
+		name  string
+		inst  instrument.Instrument
+		sigma float64
+		want  error
+		field string
+	}{
+		{
+			name:  "equity is not an option",
+			inst:  underlying,
+			sigma: 0.2,
+			want:  ErrUnsupportedInstrument,
+		},
+		{
+			name:  "expired option",
+			inst:  instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), now.Add(-24*time.Hour), instrument.Call),
+			sigma: 0.2,
+			want:  ErrExpired,
+		},
+		{
+			name:  "zero volatility",
+			inst:  instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), nextYear, instrument.Call),
+			sigma: 0,
+			want:  ErrInvalidInput,
+			field: "volatility",
+		},
+		{
+			name:  "zero strike",
+			inst:  instrument.NewEuropeanOption("OPT", underlying, decimal.Zero, nextYear, instrument.Put),
+			sigma: 0.2,
+			want:  ErrInvalidInput,
+			field: "strike",
+		},
+		{
+			name:  "unknown underlying",
+			inst:  instrument.NewEuropeanOption("OPT", instrument.NewEquity("X", "USD", "X"), decimal.NewFromInt(100), nextYear, instrument.Put),
+			sigma: 0.2,
+			want:  ErrMissingMarketData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pricers := []Pricer{
+				NewBlackScholesPricer(mkt, 0.05, tt.sigma),
+				NewMonteCarloPricer(mkt, 1000, 0.05, tt.sigma),
+			}
+			for _, p := range pricers {
+				_, err := p.Price(context.Background(), tt.inst)
+				assert.ErrorIs(t, err, tt.want, "%T", p)
+
+				if tt.field != "" {
+					var inputErr *InputError
+					if assert.ErrorAs(t, err, &inputErr) {
+						assert.Equal(t, tt.field, inputErr.Field)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+)
+
+// exoticTerms returns the vanilla terms embedded in an exotic option, or
+// nil if inst is not one.
+func exoticTerms(inst instrument.Instrument) *instrument.Option {
+	switch e := inst.(type) {
+	case *instrument.BarrierOption:
+		return e.Option
+	case *instrument.AsianOption:
+		return e.Option
+	case *instrument.LookbackOption:
+		return e.Option
+	case *instrument.DigitalOption:
+		return e.Option
+	}
+	return nil
+}
+
+// resolveExotic resolves the inputs of an exotic option like resolveInputs.
+// Floating-strike lookbacks have no strike; they report the spot instead.
+func resolveExotic(mkt *market.Snapshot, inst instrument.Instrument, r, sigma float64) (Inputs, error) {
+	opt := exoticTerms(inst)
+	in, err := marketInputs(mkt, opt, opt.Strike().InexactFloat64(), r, sigma)
+	if err != nil {
+		return Inputs{}, err
+	}
+	if l, ok := inst.(*instrument.LookbackOption); ok && l.StrikeType() == instrument.FloatingStrike {
+		in.Strike = in.Spot
+	}
+	if err := in.Validate(); err != nil {
+		return Inputs{}, err
+	}
+
+	switch e := inst.(type) {
+	case *instrument.BarrierOption:
+		if err := positive("barrier", e.Barrier().InexactFloat64()); err != nil {
+			return Inputs{}, err
+		}
+		if rebate := e.Rebate().InexactFloat64(); rebate < 0 {
+			return Inputs{}, &InputError{Field: "rebate", Value: rebate}
+		}
+	case *instrument.AsianOption:
+		if _, err := fixingTimes(valuationTime(mkt), e); err != nil {
+			return Inputs{}, err
+		}
+	}
+	return in, nil
+}
+
+// fixingTimes returns the fixing dates of an Asian option in years from
+// asOf. Past fixings are not supported, as no fixing history is available.
+func fixingTimes(asOf time.Time, a *instrument.AsianOption) ([]float64, error) {
+	fixings := a.Fixings()
+	if len(fixings) == 0 {
+		return nil, fmt.Errorf("%w: asian option %s has no fixings", ErrInvalidInput, a.ID())
+	}
+	times := make([]float64, len(fixings))
+	for i, d := range fixings {
+		if times[i] = yearFraction(asOf, d); times[i] <= 0 {
+			return nil, fmt.Errorf("%w: fixing of %s on %s has passed", ErrMissingMarketData, a.ID(), d.Format(time.DateOnly))
+		}
+	}
+	return times, nil
+}
+
+func (bs *BlackScholesPricer) priceExotic(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+	if err := ctx.Err(); err != nil {
+		return PricingResult{}, err
+	}
+	in, err := resolveExotic(bs.Market, inst, bs.RiskFreeRate, bs.Volatility)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	price, err := analyticExotic(in, inst, valuationTime(bs.Market))
+	if err != nil {
+		return PricingResult{}, err
+	}
+	return PricingResult{
+		Price:  money.NewFromFloat(price, inst.Currency()),
+		Model:  ModelBlackScholes,
+		Inputs: in,
+	}, nil
+}
+
+func (bs *BlackScholesPricer) exoticGreeks(ctx context.Context, inst instrument.Instrument) (Greeks, error) {
+	if err := ctx.Err(); err != nil {
+		return Greeks{}, err
+	}
+	in, err := resolveExotic(bs.Market, inst, bs.RiskFreeRate, bs.Volatility)
+	if err != nil {
+		return Greeks{}, err
+	}
+	asOf := valuationTime(bs.Market)
+	return BumpGreeks(in, DefaultBumpSizes, func(x Inputs) (float64, error) {
+		if err := x.Validate(); err != nil {
+			return 0, err
+		}
+		// Fixing dates stay put while the valuation time moves
+		return analyticExotic(x, inst, asOf.Add(time.Duration((in.Expiry-x.Expiry)*365*24*float64(time.Hour))))
+	})
+}
+
+// analyticExotic returns the closed-form Black-Scholes value of an exotic
+// option. Arithmetic Asians have no closed form, nor has anything but a
+// digital under discrete dividends, which pays on the escrowed spot.
+func analyticExotic(in Inputs, inst instrument.Instrument, asOf time.Time) (float64, error) {
+	if len(in.Dividends) > 0 {
+		if _, ok := inst.(*instrument.DigitalOption); !ok {
+			return 0, fmt.Errorf("%w: no closed form for %s under discrete dividends", ErrUnsupportedInstrument, inst.ID())
+		}
+		in = in.escrowed()
+	}
+	switch e := inst.(type) {
+	case *instrument.BarrierOption:
+		return barrierPrice(in, e.OptionType(), e.BarrierType(), e.Barrier().InexactFloat64(), e.Rebate().InexactFloat64()), nil
+	case *instrument.AsianOption:
+		if e.Averaging() != instrument.GeometricAverage {
+			return 0, fmt.Errorf("%w: no closed form for %s asian %s", ErrUnsupportedInstrument, e.Averaging(), e.ID())
+		}
+		times, err := fixingTimes(asOf, e)
+		if err != nil {
+			return 0, err
+		}
+		return geometricAsianPrice(in, e.OptionType(), times), nil
+	case *instrument.LookbackOption:
+		if e.StrikeType() == instrument.FloatingStrike {
+			return floatingLookbackPrice(in, e.OptionType(), in.Spot, in.Spot), nil
+		}
+		return fixedLookbackPrice(in, e.OptionType(), in.Spot, in.Spot), nil
+	case *instrument.DigitalOption:
+		return digitalPrice(in, e.OptionType(), e.Payout(), e.Cash().InexactFloat64()), nil
+	}
+	return 0, unsupported(inst)
+}
+
+// barrierPrice is the Reiner and Rubinstein (1991) value of a continuously
+// monitored barrier option, in the notation of Haug (2007). Out rebates are
+// paid at the hit, in rebates at expiry.
+func barrierPrice(in Inputs, optType instrument.OptionType, bt instrument.BarrierType, H, R float64) float64 {
+	S, K, T := in.Spot, in.Strike, in.Expiry
+	r, b, sigma := in.RiskFreeRate, in.RiskFreeRate-in.DividendYield, in.Volatility
+
+	// Already breached: an out option pays its rebate now, an in option
+	// is a vanilla
+	if (bt.Up() && S >= H) || (!bt.Up() && S <= H) {
+		if bt.In() {
+			return bsPrice(in, optType)
+		}
+		return R
+	}
+
+	phi, eta := 1.0, 1.0
+	if optType == instrument.Put {
+		phi = -1
+	}
+	if bt.Up() {
+		eta = -1
+	}
+
+	sigmaT := sigma * math.Sqrt(T)
+	mu := (b - 0.5*sigma*sigma) / (sigma * sigma)
+	lambda := math.Sqrt(mu*mu + 2*r/(sigma*sigma))
+	x1 := math.Log(S/K)/sigmaT + (1+mu)*sigmaT
+	x2 := math.Log(S/H)/sigmaT + (1+mu)*sigmaT
+	y1 := math.Log(H*H/(S*K))/sigmaT + (1+mu)*sigmaT
+	y2 := math.Log(H/S)/sigmaT + (1+mu)*sigmaT
+	z := math.Log(H/S)/sigmaT + lambda*sigmaT
+
+	carry := S * math.Exp((b-r)*T)
+	disc := K * math.Exp(-r*T)
+	hs := H / S
+	A := phi*carry*normCdf(phi*x1) - phi*disc*normCdf(phi*x1-phi*sigmaT)
+	B := phi*carry*normCdf(phi*x2) - phi*disc*normCdf(phi*x2-phi*sigmaT)
+	C := phi*carry*math.Pow(hs, 2*(mu+1))*normCdf(eta*y1) - phi*disc*math.Pow(hs, 2*mu)*normCdf(eta*y1-eta*sigmaT)
+	D := phi*carry*math.Pow(hs, 2*(mu+1))*normCdf(eta*y2) - phi*disc*math.Pow(hs, 2*mu)*normCdf(eta*y2-eta*sigmaT)
+	E := R * math.Exp(-r*T) * (normCdf(eta*x2-eta*sigmaT) - math.Pow(hs, 2*mu)*normCdf(eta*y2-eta*sigmaT))
+	F := R * (math.Pow(hs, mu+lambda)*normCdf(eta*z) + math.Pow(hs, mu-lambda)*normCdf(eta*z-2*eta*lambda*sigmaT))
+
+	above := K > H
+	call := optType == instrument.Call
+	switch {
+	case bt == instrument.DownAndIn && call:
+		return pick(above, C+E, A-B+D+E)
+	case bt == instrument.UpAndIn && call:
+		return pick(above, A+E, B-C+D+E)
+	case bt == instrument.DownAndIn:
+		return pick(above, B-C+D+E, A+E)
+	case bt == instrument.UpAndIn:
+		return pick(above, A-B+D+E, C+E)
+	case bt == instrument.DownAndOut && call:
+		return pick(above, A-C+F, B-D+F)
+	case bt == instrument.UpAndOut && call:
+		return pick(above, F, A-B+C-D+F)
+	case bt == instrument.DownAndOut:
+		return pick(above, A-B+C-D+F, F)
+	default: // up-and-out put
+		return pick(above, B-D+F, A-C+F)
+	}
+}
+
+func pick(cond bool, a, b float64) float64 {
+	if cond {
+		return a
+	}
+	return b
+}
+
+// geometricAsianPrice values an option on the geometric average of the spot
+// at the fixing times, which is lognormal under Black-Scholes.
+func geometricAsianPrice(in Inputs, optType instrument.OptionType, times []float64) float64 {
+	n := float64(len(times))
+	sigma := in.Volatility
+	drift := in.RiskFreeRate - in.DividendYield - 0.5*sigma*sigma
+
+	mean := math.Log(in.Spot)
+	variance := 0.0
+	for i, ti := range times {
+		mean += drift * ti / n
+		for _, tj := range times[:i] {
+			variance += 2 * math.Min(ti, tj)
+		}
+		variance += ti
+	}
+	variance *= sigma * sigma / (n * n)
+
+	T := times[len(times)-1]
+	forward := math.Exp(mean + 0.5*variance)
+	sd := math.Sqrt(variance)
+	d1 := (math.Log(forward/in.Strike) + 0.5*variance) / sd
+	d2 := d1 - sd
+	df := math.Exp(-in.RiskFreeRate * T)
+	if optType == instrument.Call {
+		return df * (forward*normCdf(d1) - in.Strike*normCdf(d2))
+	}
+	return df * (in.Strike*normCdf(-d2) - forward*normCdf(-d1))
+}
+
+// lookbackCarry returns the cost of carry r-q, kept away from zero where
+// the lookback formulas are singular.
+func lookbackCarry(in Inputs) float64 {
+	b := in.RiskFreeRate - in.DividendYield
+	if math.Abs(b) < 1e-6 {
+		return math.Copysign(1e-6, b)
+	}
+	return b
+}
+
+// floatingLookbackPrice is the Goldman, Sosin and Gatto (1979) value of a
+// floating-strike lookback given the running minimum and maximum.
+func floatingLookbackPrice(in Inputs, optType instrument.OptionType, sMin, sMax float64) float64 {
+	S, T, r, sigma := in.Spot, in.Expiry, in.RiskFreeRate, in.Volatility
+	b := lookbackCarry(in)
+	sqrtT := math.Sqrt(T)
+	k := sigma * sigma / (2 * b)
+	carry := S * math.Exp((b-r)*T)
+	df := math.Exp(-r * T)
+
+	if optType == instrument.Call {
+		a1 := (math.Log(S/sMin) + (b+0.5*sigma*sigma)*T) / (sigma * sqrtT)
+		a2 := a1 - sigma*sqrtT
+		return carry*normCdf(a1) - sMin*df*normCdf(a2) +
+			S*df*k*(math.Pow(S/sMin, -1/k)*normCdf(-a1+2*b*sqrtT/sigma)-math.Exp(b*T)*normCdf(-a1))
+	}
+	b1 := (math.Log(S/sMax) + (b+0.5*sigma*sigma)*T) / (sigma * sqrtT)
+	b2 := b1 - sigma*sqrtT
+	return sMax*df*normCdf(-b2) - carry*normCdf(-b1) +
+		S*df*k*(-math.Pow(S/sMax, -1/k)*normCdf(b1-2*b*sqrtT/sigma)+math.Exp(b*T)*normCdf(b1))
+}
+
+// fixedLookbackPrice is the Conze and Viswanathan (1991) value of a
+// fixed-strike lookback given the running minimum and maximum.
+func fixedLookbackPrice(in Inputs, optType instrument.OptionType, sMin, sMax float64) float64 {
+	S, K, T, r, sigma := in.Spot, in.Strike, in.Expiry, in.RiskFreeRate, in.Volatility
+	b := lookbackCarry(in)
+	sqrtT := math.Sqrt(T)
+	k := sigma * sigma / (2 * b)
+	carry := S * math.Exp((b-r)*T)
+	df := math.Exp(-r * T)
+	d := func(x float64) float64 {
+		return (math.Log(S/x) + (b+0.5*sigma*sigma)*T) / (sigma * sqrtT)
+	}
+
+	if optType == instrument.Call {
+		// The payoff is the larger of K and the running maximum, less K
+		x, intrinsic := K, 0.0
+		if K <= sMax {
+			x, intrinsic = sMax, df*(sMax-K)
+		}
+		d1 := d(x)
+		return intrinsic + carry*normCdf(d1) - x*df*normCdf(d1-sigma*sqrtT) +
+			S*df*k*(-math.Pow(S/x, -1/k)*normCdf(d1-2*b*sqrtT/sigma)+math.Exp(b*T)*normCdf(d1))
+	}
+	x, intrinsic := K, 0.0
+	if K >= sMin {
+		x, intrinsic = sMin, df*(K-sMin)
+	}
+	d1 := d(x)
+	return intrinsic + x*df*normCdf(-d1+sigma*sqrtT) - carry*normCdf(-d1) +
+		S*df*k*(math.Pow(S/x, -1/k)*normCdf(-d1+2*b*sqrtT/sigma)-math.Exp(b*T)*normCdf(-d1))
+}
+
+// digitalPrice is the Black-Scholes value of a cash- or asset-or-nothing
+// option.
+func digitalPrice(in Inputs, optType instrument.OptionType, payout instrument.DigitalPayout, cash float64) float64 {
+	d1, d2 := bsD1D2(in)
+	phi := 1.0
+	if optType == instrument.Put {
+		phi = -1
+	}
+	if payout == instrument.AssetOrNothing {
+		return in.Spot * math.Exp(-in.DividendYield*in.Expiry) * normCdf(phi*d1)
+	}
+	return cash * math.Exp(-in.RiskFreeRate*in.Expiry) * normCdf(phi*d2)
+}
@@ -0,0 +1,196 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBarrierPrice_Haug(t *testing.T) {
+	// Haug (2007), table 4-13: S=100, T=0.5, r=0.08, b=0.04, sigma=0.25, rebate 3
+	in := Inputs{Spot: 100, Expiry: 0.5, RiskFreeRate: 0.08, DividendYield: 0.04, Volatility: 0.25}
+	tests := []struct {
+		optType instrument.OptionType
+		bt      instrument.BarrierType
+		barrier float64
+		want    [3]float64 // K = 90, 100, 110
+	}{
+		{instrument.Call, instrument.DownAndOut, 95, [3]float64{9.0246, 6.7924, 4.8759}},
+		{instrument.Call, instrument.UpAndOut, 105, [3]float64{2.6789, 2.3580, 2.3453}},
+		{instrument.Call, instrument.DownAndIn, 95, [3]float64{7.7627, 4.0109, 2.0576}},
+		{instrument.Call, instrument.UpAndIn, 105, [3]float64{14.1112, 8.4482, 4.5910}},
+		{instrument.Put, instrument.DownAndOut, 95, [3]float64{2.2798, 2.2947, 2.6252}},
+		{instrument.Put, instrument.UpAndOut, 105, [3]float64{3.7760, 5.4932, 7.5187}},
+		{instrument.Put, instrument.DownAndIn, 95, [3]float64{2.9586, 6.5677, 11.9752}},
+		{instrument.Put, instrument.UpAndIn, 105, [3]float64{1.4653, 3.3721, 7.0846}},
+	}
+	for _, tt := range tests {
+		for i, K := range []float64{90, 100, 110} {
+			in.Strike = K
+			got := barrierPrice(in, tt.optType, tt.bt, tt.barrier, 3)
+			assert.InDelta(t, tt.want[i], got, 1e-4, "%s %s K=%v", tt.bt, tt.optType, K)
+		}
+	}
+}
+
+func TestBarrierPrice_InOutParity(t *testing.T) {
+	in := Inputs{Spot: 100, Expiry: 1, RiskFreeRate: 0.05, DividendYield: 0.02, Volatility: 0.3}
+	for _, optType := range []instrument.OptionType{instrument.Call, instrument.Put} {
+		for _, K := range []float64{80, 100, 120} {
+			in.Strike = K
+			vanilla := bsPrice(in, optType)
+			down := barrierPrice(in, optType, instrument.DownAndIn, 90, 0) + barrierPrice(in, optType, instrument.DownAndOut, 90, 0)
+			up := barrierPrice(in, optType, instrument.UpAndIn, 110, 0) + barrierPrice(in, optType, instrument.UpAndOut, 110, 0)
+			assert.InDelta(t, vanilla, down, 1e-10, "down %s K=%v", optType, K)
+			assert.InDelta(t, vanilla, up, 1e-10, "up %s K=%v", optType, K)
+		}
+	}
+}
+
+func TestLookbackAndDigitalPrice_Haug(t *testing.T) {
+	// Haug (2007), floating strike lookback call with b = 0.04
+	in := Inputs{Spot: 120, Expiry: 0.5, RiskFreeRate: 0.10, DividendYield: 0.06, Volatility: 0.30}
+	assert.InDelta(t, 25.3533, floatingLookbackPrice(in, instrument.Call, 100, 120), 1e-4)
+
+	// Cash-or-nothing put
+	in = Inputs{Spot: 100, Strike: 80, Expiry: 0.75, RiskFreeRate: 0.06, DividendYield: 0.06, Volatility: 0.35}
+	assert.InDelta(t, 2.6710, digitalPrice(in, instrument.Put, instrument.CashOrNothing, 10), 1e-4)
+
+	// Asset-or-nothing put
+	in = Inputs{Spot: 70, Strike: 65, Expiry: 0.5, RiskFreeRate: 0.07, DividendYield: 0.05, Volatility: 0.27}
+	assert.InDelta(t, 20.2069, digitalPrice(in, instrument.Put, instrument.AssetOrNothing, 0), 1e-4)
+
+	// Cash-or-nothing plus asset-or-nothing replicate the vanilla
+	in.Strike = 70
+	call := digitalPrice(in, instrument.Call, instrument.AssetOrNothing, 0) - digitalPrice(in, instrument.Call, instrument.CashOrNothing, in.Strike)
+	assert.InDelta(t, bsPrice(in, instrument.Call), call, 1e-10)
+}
+
+func TestGeometricAsianPrice_SingleFixingIsVanilla(t *testing.T) {
+	in := Inputs{Spot: 100, Strike: 95, Expiry: 1.5, RiskFreeRate: 0.04, DividendYield: 0.01, Volatility: 0.25}
+	for _, optType := range []instrument.OptionType{instrument.Call, instrument.Put} {
+		assert.InDelta(t, bsPrice(in, optType), geometricAsianPrice(in, optType, []float64{in.Expiry}), 1e-10)
+	}
+}
+
+// exoticFixture returns a market, an underlying and the monthly fixing
+// dates of a one-year Asian option.
+func exoticFixture(now time.Time) (*market.Snapshot, *instrument.Equity, []time.Time) {
+	mkt := market.NewSnapshot(now).
+		SetSpot("AAPL", 100).
+		SetVolatility("AAPL", 0.25).
+		SetDividendYield("AAPL", 0.02)
+	fixings := make([]time.Time, 12)
+	for i := range fixings {
+		fixings[i] = yearsFrom(now, float64(i+1)/12)
+	}
+	return mkt, instrument.NewEquity("AAPL", "USD", "AAPL"), fixings
+}
+
+func TestMonteCarloPricer_ExoticsMatchClosedForms(t *testing.T) {
+	now := time.Now()
+	mkt, underlying, fixings := exoticFixture(now)
+	expiry := yearsFrom(now, 1)
+	K := decimal.NewFromInt(100)
+
+	options := []instrument.Instrument{
+		instrument.NewBarrierOption("DOC", underlying, K, expiry, instrument.Call, instrument.DownAndOut, decimal.NewFromInt(90), decimal.NewFromInt(2)),
+		instrument.NewBarrierOption("UIC", underlying, K, expiry, instrument.Call, instrument.UpAndIn, decimal.NewFromInt(120), decimal.NewFromInt(1)),
+		instrument.NewBarrierOption("UOP", underlying, K, expiry, instrument.Put, instrument.UpAndOut, decimal.NewFromInt(115), decimal.Zero),
+		instrument.NewAsianOption("GEO", underlying, K, fixings, instrument.Call, instrument.GeometricAverage),
+		instrument.NewLookbackOption("FLT", underlying, decimal.Zero, expiry, instrument.Call, instrument.FloatingStrike),
+		instrument.NewLookbackOption("FIX", underlying, decimal.NewFromInt(105), expiry, instrument.Put, instrument.FixedStrike),
+		instrument.NewCashOrNothingOption("CON", underlying, K, expiry, instrument.Call, decimal.NewFromInt(10)),
+		instrument.NewAssetOrNothingOption("AON", underlying, K, expiry, instrument.Put),
+	}
+
+	bs := NewBlackScholesPricer(mkt, 0.05, 0)
+	mc := NewMonteCarloPricer(mkt, 100000, 0.05, 0)
+	mc.Seed = 7
+	mc.Steps = 250
+	for _, opt := range options {
+		want, err := bs.Price(context.Background(), opt)
+		assert.NoError(t, err, opt.ID())
+		got, err := mc.Price(context.Background(), opt)
+		assert.NoError(t, err, opt.ID())
+
+		// Discrete monitoring leaves a small bias after the correction
+		tol := 3*got.StdErr + 0.005*want.Price.Amount().InexactFloat64()
+		assert.InDelta(t, want.Price.Amount().InexactFloat64(), got.Price.Amount().InexactFloat64(), tol, opt.ID())
+		assert.Equal(t, ModelMonteCarlo, got.Model)
+	}
+}
+
+func TestMonteCarloPricer_ArithmeticAsianControlVariate(t *testing.T) {
+	now := time.Now()
+	mkt, underlying, fixings := exoticFixture(now)
+	asian := instrument.NewAsianOption("ASIAN", underlying, decimal.NewFromInt(100), fixings, instrument.Call, instrument.ArithmeticAverage)
+
+	mc := NewMonteCarloPricer(mkt, 50000, 0.05, 0)
+	mc.Seed = 11
+	plain, err := mc.Price(context.Background(), asian)
+	assert.NoError(t, err)
+
+	mc.VarianceReduction = ControlVariate
+	controlled, err := mc.Price(context.Background(), asian)
+	assert.NoError(t, err)
+
+	assert.Less(t, controlled.StdErr, plain.StdErr/10)
+	assert.InDelta(t, plain.Price.Amount().InexactFloat64(), controlled.Price.Amount().InexactFloat64(), 4*plain.StdErr)
+
+	// The arithmetic average dominates the geometric one
+	geometric := instrument.NewAsianOption("GEO", underlying, decimal.NewFromInt(100), fixings, instrument.Call, instrument.GeometricAverage)
+	geo, err := NewBlackScholesPricer(mkt, 0.05, 0).Price(context.Background(), geometric)
+	assert.NoError(t, err)
+	assert.Greater(t, controlled.Price.Amount().InexactFloat64(), geo.Price.Amount().InexactFloat64())
+}
+
+func TestBlackScholesPricer_ExoticErrors(t *testing.T) {
+	now := time.Now()
+	mkt, underlying, fixings := exoticFixture(now)
+	bs := NewBlackScholesPricer(mkt, 0.05, 0)
+
+	asian := instrument.NewAsianOption("ASIAN", underlying, decimal.NewFromInt(100), fixings, instrument.Call, instrument.ArithmeticAverage)
+	_, err := bs.Price(context.Background(), asian)
+	assert.True(t, errors.Is(err, ErrUnsupportedInstrument))
+
+	past := append([]time.Time{now.Add(-24 * time.Hour)}, fixings...)
+	seasoned := instrument.NewAsianOption("SEASONED", underlying, decimal.NewFromInt(100), past, instrument.Call, instrument.GeometricAverage)
+	_, err = bs.Price(context.Background(), seasoned)
+	assert.True(t, errors.Is(err, ErrMissingMarketData))
+
+	barrier := instrument.NewBarrierOption("BAR", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call, instrument.DownAndOut, decimal.NewFromInt(90), decimal.NewFromInt(-1))
+	_, err = bs.Price(context.Background(), barrier)
+	var inputErr *InputError
+	assert.True(t, errors.As(err, &inputErr))
+	assert.Equal(t, "rebate", inputErr.Field)
+}
+
+func TestPricers_ExoticGreeks(t *testing.T) {
+	now := time.Now()
+	mkt, underlying, fixings := exoticFixture(now)
+	asian := instrument.NewAsianOption("GEO", underlying, decimal.NewFromInt(100), fixings, instrument.Call, instrument.GeometricAverage)
+
+	g, err := NewBlackScholesPricer(mkt, 0.05, 0).Greeks(context.Background(), asian)
+	assert.NoError(t, err)
+	// An averaging call is less sensitive than the vanilla
+	vanilla := bsGreeks(Inputs{Spot: 100, Strike: 100, Expiry: 1, RiskFreeRate: 0.05, DividendYield: 0.02, Volatility: 0.25}, instrument.Call)
+	assert.Greater(t, g.Delta, 0.0)
+	assert.Less(t, g.Delta, vanilla.Delta)
+	assert.Less(t, g.Vega, vanilla.Vega)
+	assert.Less(t, g.Theta, 0.0)
+
+	mc := NewMonteCarloPricer(mkt, 50000, 0.05, 0)
+	mc.Seed = 3
+	mg, err := mc.Greeks(context.Background(), asian)
+	assert.NoError(t, err)
+	assert.InDelta(t, g.Delta, mg.Delta, 0.02)
+	assert.InDelta(t, g.Vega, mg.Vega, 0.05*math.Abs(g.Vega))
+}
@@ -0,0 +1,558 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+)
+
+// ExerciseMethod selects how FiniteDifferencePricer enforces early
+// exercise in the implicit part of each time step.
+type ExerciseMethod string
+
+const (
+	// PSOR solves the linear complementarity problem by projected
+	// successive over-relaxation.
+	PSOR ExerciseMethod = "psor"
+	// Penalty adds a large penalty wherever the value falls below the
+	// exercise value and iterates on the active set (Forsyth and Vetzal, 2002).
+	Penalty ExerciseMethod = "penalty"
+)
+
+const (
+	// DefaultFDTimeSteps is the number of time steps used when TimeSteps is zero.
+	DefaultFDTimeSteps = 200
+	// DefaultFDSpaceSteps is the number of spot intervals used when
+	// SpaceSteps is zero.
+	DefaultFDSpaceSteps = 200
+	// DefaultRannacherSteps is the number of initial time steps replaced by
+	// implicit half steps when RannacherSteps is zero.
+	DefaultRannacherSteps = 2
+	// DefaultConcentration is the grid concentration used when
+	// Concentration is zero.
+	DefaultConcentration = 0.1
+)
+
+const (
+	// fdStdDevs is how many standard deviations of the log spot the grid
+	// reaches above the larger of the spot and the strike.
+	fdStdDevs      = 6
+	psorRelaxation = 1.2
+	psorTolerance  = 1e-10
+	psorMaxIter    = 10000
+	penaltyFactor  = 1e8
+	penaltyMaxIter = 100
+)
+
+// FiniteDifferencePricer values European and American options by solving
+// the Black-Scholes PDE backwards from expiry with a theta scheme.
+// Crank-Nicolson is used by default; its first steps are replaced by fully
+// implicit half steps (Rannacher smoothing) so that the kink of the payoff
+// does not leave oscillations in delta and gamma. The spot grid is spaced
+// by a sinh transform that concentrates nodes around the strike, which
+// sits on a node. RiskFreeRate and Volatility are defaults used when the
+// market snapshot does not quote them.
+type FiniteDifferencePricer struct {
+	Market       *market.Snapshot
+	RiskFreeRate float64
+	Volatility   float64
+	TimeSteps    int
+	SpaceSteps   int
+	// Theta weights the implicit part of each step: 0.5 is Crank-Nicolson,
+	// the default when zero, and 1 fully implicit. Explicit schemes are not
+	// offered; their stability limit is impractical on concentrated grids.
+	Theta float64
+	// RannacherSteps is the number of initial time steps each replaced by
+	// two fully implicit half steps. Zero uses DefaultRannacherSteps and a
+	// negative value turns smoothing off.
+	RannacherSteps int
+	// Concentration sets how tightly nodes cluster around the strike, as a
+	// fraction of the strike; smaller values concentrate more. Zero uses
+	// DefaultConcentration.
+	Concentration float64
+	// Exercise selects the early-exercise method; PSOR by default.
+	Exercise ExerciseMethod
+	// LocalVol, when set, replaces the flat volatility in the PDE by a
+	// Dupire local volatility, applied to the escrowed spot under discrete
+	// dividends. The volatility no longer moves with Volatility, so Vega
+	// is zero.
+	LocalVol *LocalVolatility
+}
+
+// NewFiniteDifferencePricer creates a new Crank-Nicolson pricer valuing
+// against mkt on a grid of timeSteps by spaceSteps.
+func NewFiniteDifferencePricer(mkt *market.Snapshot, timeSteps, spaceSteps int, r, sigma float64) *FiniteDifferencePricer {
+	return &FiniteDifferencePricer{
+		Market:       mkt,
+		RiskFreeRate: r,
+		Volatility:   sigma,
+		TimeSteps:    timeSteps,
+		SpaceSteps:   spaceSteps,
+	}
+}
+
+var (
+	_ Pricer           = (*FiniteDifferencePricer)(nil)
+	_ GreeksCalculator = (*FiniteDifferencePricer)(nil)
+)
+
+// fdResult is the solution at the spot together with the Greeks read off
+// the grid.
+type fdResult struct {
+	price float64
+	delta float64
+	gamma float64
+	theta float64
+	// iterations counts the PSOR sweeps or penalty solves spent on early
+	// exercise.
+	iterations int
+}
+
+// Price values a European or American option on the grid.
+func (fd *FiniteDifferencePricer) Price(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+	opt, in, err := fd.resolve(inst)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	res, err := fd.solve(ctx, in, opt)
+	if err != nil {
+		return PricingResult{}, err
+	}
+
+	diagnostics := map[string]float64{
+		"time steps":  float64(fd.timeSteps()),
+		"space steps": float64(fd.spaceSteps()),
+	}
+	if opt.Style() == instrument.American {
+		diagnostics["exercise iterations"] = float64(res.iterations)
+	}
+	return PricingResult{
+		Price:       money.NewFromFloat(res.price, opt.Currency()),
+		Model:       ModelFiniteDifference,
+		Inputs:      in,
+		Diagnostics: diagnostics,
+	}, nil
+}
+
+// Greeks returns delta, gamma and theta read off the grid and vega and rho
+// by solving again under bumped inputs. Vanna, Volga and Charm are not
+// reported.
+func (fd *FiniteDifferencePricer) Greeks(ctx context.Context, inst instrument.Instrument) (Greeks, error) {
+	opt, in, err := fd.resolve(inst)
+	if err != nil {
+		return Greeks{}, err
+	}
+	res, err := fd.solve(ctx, in, opt)
+	if err != nil {
+		return Greeks{}, err
+	}
+
+	revalue := func(dv, dr float64) (float64, error) {
+		x := in
+		x.Volatility += dv
+		x.RiskFreeRate += dr
+		r, err := fd.solve(ctx, x, opt)
+		return r.price, err
+	}
+	dv, dr := DefaultBumpSizes.Volatility, DefaultBumpSizes.Rate
+	// Under local volatility a bump would only move the grid, which is
+	// sized from the flat volatility
+	var vega float64
+	if fd.LocalVol == nil {
+		vUp, err := revalue(dv, 0)
+		if err != nil {
+			return Greeks{}, err
+		}
+		vDown, err := revalue(-dv, 0)
+		if err != nil {
+			return Greeks{}, err
+		}
+		vega = (vUp - vDown) / (2 * dv)
+	}
+	rUp, err := revalue(0, dr)
+	if err != nil {
+		return Greeks{}, err
+	}
+	rDown, err := revalue(0, -dr)
+	if err != nil {
+		return Greeks{}, err
+	}
+
+	return Greeks{
+		Delta: res.delta,
+		Gamma: res.gamma,
+		Theta: res.theta,
+		Vega:  vega,
+		Rho:   (rUp - rDown) / (2 * dr),
+	}, nil
+}
+
+func (fd *FiniteDifferencePricer) resolve(inst instrument.Instrument) (*instrument.Option, Inputs, error) {
+	opt, err := asOption(inst)
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	if opt.Style() == instrument.Bermudan {
+		return nil, Inputs{}, fmt.Errorf("%w: %s exercise of %s", ErrUnsupportedInstrument, opt.Style(), opt.ID())
+	}
+	in, err := resolveInputs(fd.Market, opt, fd.RiskFreeRate, fd.Volatility)
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	switch {
+	case fd.TimeSteps < 0:
+		return nil, Inputs{}, &InputError{Field: "time steps", Value: float64(fd.TimeSteps)}
+	case fd.SpaceSteps != 0 && fd.SpaceSteps < 10:
+		return nil, Inputs{}, &InputError{Field: "space steps", Value: float64(fd.SpaceSteps)}
+	case fd.Theta != 0 && (fd.Theta < 0.5 || fd.Theta > 1 || math.IsNaN(fd.Theta)):
+		return nil, Inputs{}, &InputError{Field: "theta", Value: fd.Theta}
+	case fd.Concentration < 0 || math.IsNaN(fd.Concentration) || math.IsInf(fd.Concentration, 0):
+		return nil, Inputs{}, &InputError{Field: "concentration", Value: fd.Concentration}
+	}
+	switch fd.Exercise {
+	case "", PSOR, Penalty:
+	default:
+		return nil, Inputs{}, fmt.Errorf("pricing: unknown exercise method %q", fd.Exercise)
+	}
+	return opt, in, nil
+}
+
+func (fd *FiniteDifferencePricer) timeSteps() int {
+	if fd.TimeSteps == 0 {
+		return DefaultFDTimeSteps
+	}
+	return fd.TimeSteps
+}
+
+func (fd *FiniteDifferencePricer) spaceSteps() int {
+	if fd.SpaceSteps == 0 {
+		return DefaultFDSpaceSteps
+	}
+	return fd.SpaceSteps
+}
+
+func (fd *FiniteDifferencePricer) theta() float64 {
+	if fd.Theta == 0 {
+		return 0.5
+	}
+	return fd.Theta
+}
+
+func (fd *FiniteDifferencePricer) rannacherSteps() int {
+	switch {
+	case fd.RannacherSteps < 0:
+		return 0
+	case fd.RannacherSteps == 0:
+		return DefaultRannacherSteps
+	}
+	return fd.RannacherSteps
+}
+
+func (fd *FiniteDifferencePricer) concentration() float64 {
+	if fd.Concentration == 0 {
+		return DefaultConcentration
+	}
+	return fd.Concentration
+}
+
+// fdStep is one step of the time-marching scheme.
+type fdStep struct {
+	dt    float64
+	theta float64
+}
+
+// schedule returns the time steps from expiry back to valuation, with the
+// first ones split into implicit half steps.
+func (fd *FiniteDifferencePricer) schedule(expiry float64) []fdStep {
+	n := fd.timeSteps()
+	dt := expiry / float64(n)
+	smoothed := min(fd.rannacherSteps(), n)
+	steps := make([]fdStep, 0, n+smoothed)
+	for i := 0; i < smoothed; i++ {
+		steps = append(steps, fdStep{dt / 2, 1}, fdStep{dt / 2, 1})
+	}
+	for i := smoothed; i < n; i++ {
+		steps = append(steps, fdStep{dt, fd.theta()})
+	}
+	return steps
+}
+
+// fdGrid returns increasing nodes from zero to well above the larger of
+// x0 and the strike, spaced as K + c sinh(xi) for uniform xi so that they
+// cluster around the strike, which is a node.
+func fdGrid(x0, strike, sigma, expiry float64, intervals int, concentration float64) []float64 {
+	top := math.Max(x0, strike) * math.Exp(fdStdDevs*sigma*math.Sqrt(expiry))
+	top = math.Max(top, 2*math.Max(x0, strike))
+	c := concentration * strike
+	lo, hi := math.Asinh(-strike/c), math.Asinh((top-strike)/c)
+
+	// Widen the step so that a whole number of them spans [lo, 0]
+	below := max(int(math.Floor(-lo/(hi-lo)*float64(intervals))), 1)
+	dxi := -lo / float64(below)
+
+	nodes := make([]float64, intervals+1)
+	for i := range nodes {
+		nodes[i] = strike + c*math.Sinh(lo+float64(i)*dxi)
+	}
+	nodes[0], nodes[below] = 0, strike
+	return nodes
+}
+
+// fdSolver carries the grid and scratch space of one backward solve.
+type fdSolver struct {
+	in      Inputs
+	optType instrument.OptionType
+	nodes   []float64
+	// vol returns the volatility at time t and escrowed spot x.
+	vol func(t, x float64) float64
+
+	lower, diag, upper []float64
+	rhs, scratch, next []float64
+}
+
+// operator fills lower, diag and upper with the discretised generator
+//
+//	L V = 1/2 sigma^2 x^2 V_xx + (r-q) x V_x - r V
+//
+// at time t, using central differences on the non-uniform grid and
+// upwinding the drift where central differences would break the maximum
+// principle. The boundary rows are zero.
+func (s *fdSolver) operator(t float64) {
+	r, mu := s.in.RiskFreeRate, s.in.RiskFreeRate-s.in.DividendYield
+	n := len(s.nodes)
+	s.lower[0], s.diag[0], s.upper[0] = 0, 0, 0
+	s.lower[n-1], s.diag[n-1], s.upper[n-1] = 0, 0, 0
+	for i := 1; i < n-1; i++ {
+		x := s.nodes[i]
+		hm, hp := x-s.nodes[i-1], s.nodes[i+1]-x
+		sigma := s.vol(t, x)
+		a := 0.5 * sigma * sigma * x * x
+		b := mu * x
+
+		l := 2*a/(hm*(hm+hp)) - b*hp/(hm*(hm+hp))
+		d := -2*a/(hm*hp) + b*(hp-hm)/(hm*hp) - r
+		u := 2*a/(hp*(hm+hp)) + b*hm/(hp*(hm+hp))
+		if l < 0 || u < 0 {
+			l, d, u = 2*a/(hm*(hm+hp)), -2*a/(hm*hp)-r, 2*a/(hp*(hm+hp))
+			if b > 0 {
+				d, u = d-b/hp, u+b/hp
+			} else {
+				l, d = l-b/hm, d+b/hm
+			}
+		}
+		s.lower[i], s.diag[i], s.upper[i] = l, d, u
+	}
+}
+
+// payoff fills v with the exercise value at time t, when the spot at node
+// x is the escrowed x plus the dividends still to go ex.
+func (s *fdSolver) payoff(t float64, v []float64) {
+	cash, factor := s.in.dividendAdjustment(t)
+	for i, x := range s.nodes {
+		v[i] = vanillaPayoff(s.optType, (x+cash)/factor, s.in.Strike)
+	}
+}
+
+// boundaries returns the European values at the bottom and top nodes with
+// tau years left to expiry.
+func (s *fdSolver) boundaries(tau float64) (float64, float64) {
+	K, q, r := s.in.Strike, s.in.DividendYield, s.in.RiskFreeRate
+	top := s.nodes[len(s.nodes)-1]
+	if s.optType == instrument.Call {
+		return 0, top*math.Exp(-q*tau) - K*math.Exp(-r*tau)
+	}
+	return K * math.Exp(-r*tau), 0
+}
+
+// solve marches from expiry back to valuation and reads the price and
+// Greeks off the grid at the spot.
+func (fd *FiniteDifferencePricer) solve(ctx context.Context, in Inputs, opt *instrument.Option) (fdResult, error) {
+	if fd.LocalVol != nil {
+		return fd.solveWith(ctx, in, opt, fd.LocalVol.Vol)
+	}
+	return fd.solveWith(ctx, in, opt, func(float64, float64) float64 { return in.Volatility })
+}
+
+// solveWith is solve under the volatility function vol of time and
+// escrowed spot.
+func (fd *FiniteDifferencePricer) solveWith(ctx context.Context, in Inputs, opt *instrument.Option, vol func(t, x float64) float64) (fdResult, error) {
+	T := in.Expiry
+	x0 := in.escrowed().Spot
+	nodes := fdGrid(x0, in.Strike, in.Volatility, T, fd.spaceSteps(), fd.concentration())
+	n := len(nodes)
+	s := &fdSolver{
+		in:      in,
+		optType: opt.OptionType(),
+		nodes:   nodes,
+		vol:     vol,
+		lower:   make([]float64, n),
+		diag:    make([]float64, n),
+		upper:   make([]float64, n),
+		rhs:     make([]float64, n),
+		scratch: make([]float64, n),
+		next:    make([]float64, n),
+	}
+	american := opt.Style() == instrument.American
+
+	values := make([]float64, n)
+	s.payoff(T, values)
+	exercise := make([]float64, n)
+	previous := make([]float64, n)
+
+	var res fdResult
+	steps := fd.schedule(T)
+	tau := 0.0
+	for k, step := range steps {
+		if k%16 == 0 && ctx.Err() != nil {
+			return fdResult{}, ctx.Err()
+		}
+		copy(previous, values)
+
+		// Explicit part at the current time
+		s.operator(T - tau)
+		for i := 1; i < n-1; i++ {
+			Lv := s.lower[i]*values[i-1] + s.diag[i]*values[i] + s.upper[i]*values[i+1]
+			s.rhs[i] = values[i] + (1-step.theta)*step.dt*Lv
+		}
+
+		// Implicit part at the next time, with Dirichlet boundary rows
+		tau += step.dt
+		t := math.Max(T-tau, 0)
+		s.operator(t)
+		for i := 1; i < n-1; i++ {
+			w := step.theta * step.dt
+			s.lower[i], s.diag[i], s.upper[i] = -w*s.lower[i], 1-w*s.diag[i], -w*s.upper[i]
+		}
+		s.diag[0], s.diag[n-1] = 1, 1
+		s.rhs[0], s.rhs[n-1] = s.boundaries(tau)
+		if american {
+			s.payoff(t, exercise)
+			s.rhs[0] = math.Max(s.rhs[0], exercise[0])
+			s.rhs[n-1] = math.Max(s.rhs[n-1], exercise[n-1])
+		}
+
+		if !american {
+			if err := solveTridiagonal(s.lower, s.diag, s.upper, s.rhs, values, s.scratch); err != nil {
+				return fdResult{}, err
+			}
+			continue
+		}
+		var iterations int
+		var err error
+		if fd.Exercise == Penalty {
+			iterations, err = s.penalty(values, exercise)
+		} else {
+			iterations, err = s.psor(values, exercise)
+		}
+		if err != nil {
+			return fdResult{}, err
+		}
+		res.iterations += iterations
+	}
+
+	// Interpolate at the spot, converting escrowed derivatives to spot ones
+	// with dx/dS = factor
+	_, factor := in.dividendAdjustment(0)
+	v, dv, d2v := quadraticAt(nodes, values, x0)
+	res.price = v
+	res.delta = dv * factor
+	res.gamma = d2v * factor * factor
+
+	// Theta steps forward to the previous time level at the same spot
+	last := steps[len(steps)-1].dt
+	cash, later := in.dividendAdjustment(last)
+	vLater, _, _ := quadraticAt(nodes, previous, in.Spot*later-cash)
+	res.theta = (vLater - v) / last
+	return res, nil
+}
+
+// psor solves the implicit system subject to values >= exercise, starting
+// from the current values, and returns the number of sweeps.
+func (s *fdSolver) psor(values, exercise []float64) (int, error) {
+	n := len(values)
+	for i := range values {
+		values[i] = math.Max(values[i], exercise[i])
+	}
+	values[0], values[n-1] = s.rhs[0], s.rhs[n-1]
+	for iter := 1; iter <= psorMaxIter; iter++ {
+		change := 0.0
+		for i := 1; i < n-1; i++ {
+			gs := (s.rhs[i] - s.lower[i]*values[i-1] - s.upper[i]*values[i+1]) / s.diag[i]
+			v := math.Max(exercise[i], values[i]+psorRelaxation*(gs-values[i]))
+			change = math.Max(change, math.Abs(v-values[i]))
+			values[i] = v
+		}
+		if change < psorTolerance {
+			return iter, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: PSOR did not settle in %d sweeps", ErrNoConvergence, psorMaxIter)
+}
+
+// penalty solves the implicit system with a penalty on values below the
+// exercise value, iterating until the set of penalised nodes settles, and
+// returns the number of linear solves.
+func (s *fdSolver) penalty(values, exercise []float64) (int, error) {
+	n := len(values)
+	diag := make([]float64, n)
+	rhs := make([]float64, n)
+	active := make([]bool, n)
+	for i := range active {
+		active[i] = values[i] < exercise[i]
+	}
+	for iter := 1; iter <= penaltyMaxIter; iter++ {
+		copy(diag, s.diag)
+		copy(rhs, s.rhs)
+		for i := 1; i < n-1; i++ {
+			if active[i] {
+				diag[i] += penaltyFactor
+				rhs[i] += penaltyFactor * exercise[i]
+			}
+		}
+		if err := solveTridiagonal(s.lower, diag, s.upper, rhs, s.next, s.scratch); err != nil {
+			return 0, err
+		}
+		settled := true
+		for i := 1; i < n-1; i++ {
+			now := s.next[i] < exercise[i]
+			if now != active[i] {
+				settled = false
+			}
+			active[i] = now
+		}
+		copy(values, s.next)
+		if settled {
+			for i := range values {
+				values[i] = math.Max(values[i], exercise[i])
+			}
+			return iter, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: penalty iteration did not settle in %d solves", ErrNoConvergence, penaltyMaxIter)
+}
+
+// quadraticAt returns the value and first two derivatives at x of the
+// quadratic through the three nodes nearest x.
+func quadraticAt(nodes, values []float64, x float64) (float64, float64, float64) {
+	j := 1
+	for j < len(nodes)-2 && nodes[j] < x {
+		j++
+	}
+	if j > 1 && x-nodes[j-1] < nodes[j]-x {
+		j--
+	}
+	x0, x1, x2 := nodes[j-1], nodes[j], nodes[j+1]
+	v0, v1, v2 := values[j-1], values[j], values[j+1]
+
+	// Newton divided differences
+	d01 := (v1 - v0) / (x1 - x0)
+	d12 := (v2 - v1) / (x2 - x1)
+	d012 := (d12 - d01) / (x2 - x0)
+	v := v0 + d01*(x-x0) + d012*(x-x0)*(x-x1)
+	dv := d01 + d012*(2*x-x0-x1)
+	return v, dv, 2 * d012
+}
@@ -0,0 +1,199 @@
+package pricing
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiniteDifferencePricer_EuropeanMatchesBlackScholes(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100).SetDividendYield("AAPL", 0.02)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	bs := NewBlackScholesPricer(mkt, 0.05, 0.2)
+	fd := NewFiniteDifferencePricer(mkt, 100, 200, 0.05, 0.2)
+
+	for _, strike := range []int64{90, 100, 115} {
+		for _, typ := range []instrument.OptionType{instrument.Call, instrument.Put} {
+			opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(strike), yearsFrom(now, 1), typ)
+			want, err := bs.Price(context.Background(), opt)
+			assert.NoError(t, err)
+			got, err := fd.Price(context.Background(), opt)
+			assert.NoError(t, err)
+			assert.InDelta(t, want.Value(), got.Value(), 0.002, "%s K=%d", typ, strike)
+			assert.Equal(t, ModelFiniteDifference, got.Model)
+			assert.Equal(t, 100.0, got.Diagnostics["time steps"])
+			assert.Equal(t, 200.0, got.Diagnostics["space steps"])
+
+			wantGreeks, _ := bs.Greeks(context.Background(), opt)
+			g, err := fd.Greeks(context.Background(), opt)
+			assert.NoError(t, err)
+			assert.InDelta(t, wantGreeks.Delta, g.Delta, 5e-4, "%s K=%d", typ, strike)
+			assert.InDelta(t, wantGreeks.Gamma, g.Gamma, 2e-4, "%s K=%d", typ, strike)
+			assert.InDelta(t, wantGreeks.Theta, g.Theta, 0.03, "%s K=%d", typ, strike)
+			assert.InDelta(t, wantGreeks.Vega, g.Vega, 0.05, "%s K=%d", typ, strike)
+			assert.InDelta(t, wantGreeks.Rho, g.Rho, 0.01, "%s K=%d", typ, strike)
+		}
+	}
+}
+
+func TestFiniteDifferencePricer_RannacherSmoothing(t *testing.T) {
+	// Plain Crank-Nicolson leaves the payoff kink ringing in gamma at the
+	// strike; starting with implicit half steps damps it
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	opt := instrument.NewEuropeanOption("OPT", instrument.NewEquity("AAPL", "USD", "AAPL"), decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+	want := bsGreeks(Inputs{Spot: 100, Strike: 100, Expiry: 1, RiskFreeRate: 0.05, Volatility: 0.2}, instrument.Call).Gamma
+
+	fd := NewFiniteDifferencePricer(mkt, 100, 200, 0.05, 0.2)
+	smoothed, err := fd.Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+	fd.RannacherSteps = -1
+	plain, err := fd.Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+
+	assert.InDelta(t, want, smoothed.Gamma, 1e-4)
+	assert.Greater(t, math.Abs(plain.Gamma-want), 10*math.Abs(smoothed.Gamma-want))
+
+	// Fully implicit steps need no smoothing
+	fd.Theta = 1
+	implicit, err := fd.Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.InDelta(t, want, implicit.Gamma, 2e-4)
+}
+
+func TestFiniteDifferencePricer_ConcentratedGrid(t *testing.T) {
+	// The same number of nodes is more accurate clustered at the strike
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	opt := instrument.NewEuropeanOption("OPT", instrument.NewEquity("AAPL", "USD", "AAPL"), decimal.NewFromInt(100), yearsFrom(now, 0.25), instrument.Put)
+	want := bsPrice(Inputs{Spot: 100, Strike: 100, Expiry: 0.25, RiskFreeRate: 0.05, Volatility: 0.2}, instrument.Put)
+
+	fd := NewFiniteDifferencePricer(mkt, 100, 100, 0.05, 0.2)
+	concentrated, err := fd.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	fd.Concentration = 100 // sinh is all but linear: a uniform grid
+	uniform, err := fd.Price(context.Background(), opt)
+	assert.NoError(t, err)
+
+	assert.Less(t, 5*math.Abs(concentrated.Value()-want), math.Abs(uniform.Value()-want))
+}
+
+func TestFiniteDifferencePricer_AmericanPut(t *testing.T) {
+	// Hull: S=50, K=50, r=10%, sigma=40%, T=5 months. American put ~4.28
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 50)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	expiry := yearsFrom(now, 5.0/12)
+	american := instrument.NewAmericanOption("AP", underlying, decimal.NewFromInt(50), expiry, instrument.Put)
+	european := instrument.NewEuropeanOption("EP", underlying, decimal.NewFromInt(50), expiry, instrument.Put)
+
+	tree, err := NewLatticePricer(mkt, BinomialCRR, 2000, 0.10, 0.40).Price(context.Background(), american)
+	assert.NoError(t, err)
+
+	var prices []float64
+	for _, method := range []ExerciseMethod{PSOR, Penalty} {
+		fd := NewFiniteDifferencePricer(mkt, 200, 200, 0.10, 0.40)
+		fd.Exercise = method
+		amer, err := fd.Price(context.Background(), american)
+		assert.NoError(t, err)
+		assert.InDelta(t, tree.Value(), amer.Value(), 0.002, method)
+		assert.Greater(t, amer.Diagnostics["exercise iterations"], 0.0)
+		prices = append(prices, amer.Value())
+
+		euro, err := fd.Price(context.Background(), european)
+		assert.NoError(t, err)
+		assert.Greater(t, amer.Value(), euro.Value(), "early exercise premium")
+
+		g, err := fd.Greeks(context.Background(), american)
+		assert.NoError(t, err)
+		assert.Less(t, g.Delta, 0.0)
+		assert.Greater(t, g.Delta, -1.0)
+		assert.Greater(t, g.Gamma, 0.0)
+	}
+	assert.InDelta(t, prices[0], prices[1], 1e-6, "PSOR and penalty solve the same problem")
+}
+
+func TestFiniteDifferencePricer_AmericanCallWithDividends(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST").WithDividends(
+		instrument.NewCashDividend(yearsFrom(now, 0.4), decimal.NewFromInt(6)),
+	)
+	expiry := yearsFrom(now, 0.5)
+	american := instrument.NewAmericanOption("AC", underlying, decimal.NewFromInt(90), expiry, instrument.Call)
+	european := instrument.NewEuropeanOption("EC", underlying, decimal.NewFromInt(90), expiry, instrument.Call)
+
+	fd := NewFiniteDifferencePricer(mkt, 500, 300, 0.05, 0.25)
+	euro, err := fd.Price(context.Background(), european)
+	assert.NoError(t, err)
+	want, err := NewBlackScholesPricer(mkt, 0.05, 0.25).Price(context.Background(), european)
+	assert.NoError(t, err)
+	assert.InDelta(t, want.Value(), euro.Value(), 0.005)
+
+	amer, err := fd.Price(context.Background(), american)
+	assert.NoError(t, err)
+	tree, err := NewLatticePricer(mkt, BinomialCRR, 1000, 0.05, 0.25).Price(context.Background(), american)
+	assert.NoError(t, err)
+	assert.Greater(t, amer.Value(), euro.Value()+0.5)
+	assert.InDelta(t, tree.Value(), amer.Value(), 0.02)
+}
+
+func TestFiniteDifferencePricer_Errors(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+
+	for name, fd := range map[string]*FiniteDifferencePricer{
+		"theta":         {Market: mkt, Volatility: 0.2, Theta: 0.3},
+		"space steps":   {Market: mkt, Volatility: 0.2, SpaceSteps: 4},
+		"time steps":    {Market: mkt, Volatility: 0.2, TimeSteps: -1},
+		"concentration": {Market: mkt, Volatility: 0.2, Concentration: -1},
+	} {
+		_, err := fd.Price(context.Background(), opt)
+		var inputErr *InputError
+		if assert.ErrorAs(t, err, &inputErr, name) {
+			assert.Equal(t, name, inputErr.Field)
+		}
+	}
+
+	fd := NewFiniteDifferencePricer(mkt, 0, 0, 0.05, 0.2)
+	bermudan := instrument.NewBermudanOption("B", underlying, decimal.NewFromInt(100), []time.Time{yearsFrom(now, 1)}, instrument.Put)
+	_, err := fd.Price(context.Background(), bermudan)
+	assert.ErrorIs(t, err, ErrUnsupportedInstrument)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = fd.Price(ctx, opt)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSolveTridiagonal(t *testing.T) {
+	lower := []float64{0, -1, -1, -1}
+	diag := []float64{4, 4, 4, 4}
+	upper := []float64{-1, -1, -1, 0}
+	want := []float64{1, -2, 3, 0.5}
+	rhs := make([]float64, 4)
+	for i := range rhs {
+		rhs[i] = diag[i] * want[i]
+		if i > 0 {
+			rhs[i] += lower[i] * want[i-1]
+		}
+		if i < 3 {
+			rhs[i] += upper[i] * want[i+1]
+		}
+	}
+
+	x, scratch := make([]float64, 4), make([]float64, 4)
+	assert.NoError(t, solveTridiagonal(lower, diag, upper, rhs, x, scratch))
+	assert.InDeltaSlice(t, want, x, 1e-12)
+
+	assert.ErrorIs(t, solveTridiagonal(lower, []float64{0, 1, 1, 1}, upper, rhs, x, scratch), errSingular)
+}
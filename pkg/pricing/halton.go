@@ -0,0 +1,74 @@
+package pricing
+
+import "math"
+
+// Halton is a Halton low-discrepancy RandomSource using the first prime
+// bases. Path p of a run uses point p+1 of the sequence. Halton points lose
+// uniformity in high dimensions, where Sobol is usually preferable.
+type Halton struct {
+	// RandomShift applies a Cranley-Patterson rotation drawn from the seed,
+	// so independent seeds give independent randomised estimates.
+	RandomShift bool
+}
+
+// NewStream returns the points of paths first, first+1, ...
+func (h Halton) NewStream(seed int64, _, first, dim int) NormalStream {
+	shift := make([]float64, dim)
+	if h.RandomShift {
+		rng := splitMix64(uint64(seed))
+		for k := range shift {
+			shift[k] = float64(rng.next()>>11) / (1 << 53)
+		}
+	}
+	return newQuasiNormalStream(&haltonStream{bases: firstPrimes(dim), shift: shift, n: uint64(first) + 1}, dim)
+}
+
+type haltonStream struct {
+	bases []uint64
+	shift []float64
+	n     uint64
+}
+
+func (s *haltonStream) next(u []float64) {
+	for k, b := range s.bases {
+		x := radicalInverse(s.n, b) + s.shift[k]
+		if x >= 1 {
+			x--
+		}
+		// Keep clear of 0 so the normal inverse stays finite
+		u[k] = math.Max(x, 0.5/float64(s.n+1))
+	}
+	s.n++
+}
+
+// radicalInverse mirrors the base-b digits of n about the radix point.
+func radicalInverse(n, b uint64) float64 {
+	inv := 1 / float64(b)
+	f, x := inv, 0.0
+	for ; n > 0; n /= b {
+		x += float64(n%b) * f
+		f *= inv
+	}
+	return x
+}
+
+// firstPrimes returns the first n primes.
+func firstPrimes(n int) []uint64 {
+	primes := make([]uint64, 0, n)
+	for c := uint64(2); len(primes) < n; c++ {
+		isPrime := true
+		for _, p := range primes {
+			if p*p > c {
+				break
+			}
+			if c%p == 0 {
+				isPrime = false
+				break
+			}
+		}
+		if isPrime {
+			primes = append(primes, c)
+		}
+	}
+	return primes
+}
@@ -0,0 +1,341 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sync"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+	"github.com/antigravity/go-finance-sdk/pkg/process"
+)
+
+// HestonParams are the parameters of the Heston (1993) model
+//
+//	dS = (r-q) S dt + sqrt(v) S dW1
+//	dv = Kappa(Theta - v) dt + Xi sqrt(v) dW2,  dW1 dW2 = Rho dt
+type HestonParams struct {
+	V0    float64 // initial variance
+	Kappa float64 // speed of mean reversion
+	Theta float64 // long-run variance
+	Xi    float64 // volatility of variance
+	Rho   float64 // spot-variance correlation
+}
+
+// DefaultHestonParams is the starting point of a calibration when the
+// pricer has no parameters yet.
+var DefaultHestonParams = HestonParams{V0: 0.04, Kappa: 1.5, Theta: 0.04, Xi: 0.5, Rho: -0.5}
+
+// Validate checks that the parameters define a Heston model.
+func (p HestonParams) Validate() error {
+	if err := positive("v0", p.V0); err != nil {
+		return err
+	}
+	if err := positive("kappa", p.Kappa); err != nil {
+		return err
+	}
+	if err := positive("theta", p.Theta); err != nil {
+		return err
+	}
+	if err := positive("xi", p.Xi); err != nil {
+		return err
+	}
+	if math.IsNaN(p.Rho) || p.Rho <= -1 || p.Rho >= 1 {
+		return &InputError{Field: "rho", Value: p.Rho}
+	}
+	return nil
+}
+
+// Feller reports whether 2*Kappa*Theta > Xi^2, in which case the variance
+// never reaches zero.
+func (p HestonParams) Feller() bool {
+	return 2*p.Kappa*p.Theta > p.Xi*p.Xi
+}
+
+// Process returns the Heston process for the spot, rate and dividend yield
+// of in. It is a ProcessFunc, so MonteCarloPricer can simulate the model.
+func (p HestonParams) Process(in Inputs) process.Process {
+	return process.Heston{
+		S0:    in.Spot,
+		V0:    p.V0,
+		Drift: in.RiskFreeRate - in.DividendYield,
+		Kappa: p.Kappa,
+		Theta: p.Theta,
+		Xi:    p.Xi,
+		Rho:   p.Rho,
+	}
+}
+
+// HestonPricer values European options under the Heston model with the
+// Lewis (2001) Fourier integral. RiskFreeRate is a default used when the
+// market snapshot does not quote a rate; quoted volatilities are ignored.
+type HestonPricer struct {
+	Market       *market.Snapshot
+	RiskFreeRate float64
+	Params       HestonParams
+}
+
+// NewHestonPricer creates a new Heston pricer valuing against mkt.
+func NewHestonPricer(mkt *market.Snapshot, r float64, params HestonParams) *HestonPricer {
+	return &HestonPricer{
+		Market:       mkt,
+		RiskFreeRate: r,
+		Params:       params,
+	}
+}
+
+var (
+	_ Pricer           = (*HestonPricer)(nil)
+	_ GreeksCalculator = (*HestonPricer)(nil)
+)
+
+// Price values a European option. The reported Inputs.Volatility is the
+// initial volatility sqrt(V0).
+func (hp *HestonPricer) Price(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+	opt, in, err := hp.resolve(inst)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return PricingResult{}, err
+	}
+
+	return PricingResult{
+		Price:  money.NewFromFloat(hestonPrice(in, hp.Params, opt.OptionType()), opt.Currency()),
+		Model:  ModelHeston,
+		Inputs: in,
+		Diagnostics: map[string]float64{
+			"feller": boolFloat(hp.Params.Feller()),
+		},
+	}, nil
+}
+
+// Greeks returns bump-and-revalue sensitivities. Vega and Volga are taken
+// with respect to the initial volatility sqrt(V0).
+func (hp *HestonPricer) Greeks(ctx context.Context, inst instrument.Instrument) (Greeks, error) {
+	opt, in, err := hp.resolve(inst)
+	if err != nil {
+		return Greeks{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return Greeks{}, err
+	}
+
+	return BumpGreeks(in, DefaultBumpSizes, func(x Inputs) (float64, error) {
+		if err := x.Validate(); err != nil {
+			return 0, err
+		}
+		params := hp.Params
+		params.V0 = x.Volatility * x.Volatility
+		return hestonPrice(x, params, opt.OptionType()), nil
+	})
+}
+
+func (hp *HestonPricer) resolve(inst instrument.Instrument) (*instrument.Option, Inputs, error) {
+	opt, err := asEuropeanOption(inst)
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	if err := hp.Params.Validate(); err != nil {
+		return nil, Inputs{}, err
+	}
+	in, err := resolveInputs(hp.Market, opt, hp.RiskFreeRate, math.Sqrt(hp.Params.V0))
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	in.Volatility = math.Sqrt(hp.Params.V0)
+	return opt, in, nil
+}
+
+// hestonPrice returns the Lewis (2001) price
+//
+//	C = S e^{-qT} - sqrt(SK) e^{-(r+q)T/2} / pi * Int_0^inf Re[e^{iuX} phi(u - i/2)] / (u^2 + 1/4) du
+//
+// with X = ln(S/K) + (r-q)T, and puts by put-call parity. Discrete dividends
+// are taken out of the spot as in bsPrice.
+func hestonPrice(in Inputs, p HestonParams, optType instrument.OptionType) float64 {
+	in = in.escrowed()
+	S, K, T := in.Spot, in.Strike, in.Expiry
+	r, q := in.RiskFreeRate, in.DividendYield
+	X := math.Log(S/K) + (r-q)*T
+
+	integral := integrateToInfinity(func(u float64) float64 {
+		phi := hestonCharFunc(complex(u, -0.5), T, p)
+		return real(cmplx.Exp(complex(0, u*X))*phi) / (u*u + 0.25)
+	})
+	call := S*math.Exp(-q*T) - math.Sqrt(S*K)*math.Exp(-0.5*(r+q)*T)/math.Pi*integral
+	if optType == instrument.Put {
+		return call - S*math.Exp(-q*T) + K*math.Exp(-r*T)
+	}
+	return call
+}
+
+// hestonCharFunc is the characteristic function of ln(S_T/S_0) - (r-q)T in
+// the "little Heston trap" form of Albrecher et al. (2007), which avoids
+// branch-cut discontinuities of the complex logarithm.
+func hestonCharFunc(u complex128, T float64, p HestonParams) complex128 {
+	kappa, xi := complex(p.Kappa, 0), complex(p.Xi, 0)
+	iu := complex(0, 1) * u
+	beta := kappa - complex(p.Rho, 0)*xi*iu
+	d := cmplx.Sqrt(beta*beta + xi*xi*(iu+u*u))
+	g := (beta - d) / (beta + d)
+	e := cmplx.Exp(-d * complex(T, 0))
+
+	xi2 := xi * xi
+	C := kappa * complex(p.Theta, 0) / xi2 * ((beta-d)*complex(T, 0) - 2*cmplx.Log((1-g*e)/(1-g)))
+	D := (beta - d) / xi2 * (1 - e) / (1 - g*e)
+	return cmplx.Exp(C + D*complex(p.V0, 0))
+}
+
+// integrateToInfinity integrates f over [0, inf) with 32-point
+// Gauss-Legendre panels until two consecutive panels are negligible.
+func integrateToInfinity(f func(float64) float64) float64 {
+	nodes, weights := legendre32()
+	const width = 5.0
+	total := 0.0
+	small := 0
+	for a := 0.0; a < 5000; a += width {
+		part := 0.0
+		for i, x := range nodes {
+			part += weights[i] * f(a+0.5*width*(x+1))
+		}
+		part *= 0.5 * width
+		total += part
+		if math.Abs(part) < 1e-14*math.Max(1, math.Abs(total)) {
+			if small++; small == 2 {
+				break
+			}
+		} else {
+			small = 0
+		}
+	}
+	return total
+}
+
+var legendre32 = sync.OnceValues(func() ([]float64, []float64) {
+	return gaussLegendre(32)
+})
+
+// gaussLegendre returns the nodes and weights of the n-point Gauss-Legendre
+// rule on [-1, 1], found by Newton's method on the Legendre polynomial.
+func gaussLegendre(n int) ([]float64, []float64) {
+	nodes := make([]float64, n)
+	weights := make([]float64, n)
+	for i := 0; i < (n+1)/2; i++ {
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			p0, p1 := 1.0, x
+			for k := 2; k <= n; k++ {
+				p0, p1 = p1, ((2*float64(k)-1)*x*p1-float64(k-1)*p0)/float64(k)
+			}
+			dp = float64(n) * (x*p1 - p0) / (x*x - 1)
+			dx := p1 / dp
+			x -= dx
+			if math.Abs(dx) < 1e-15 {
+				break
+			}
+		}
+		nodes[i], nodes[n-1-i] = -x, x
+		w := 2 / ((1 - x*x) * dp * dp)
+		weights[i], weights[n-1-i] = w, w
+	}
+	return nodes, weights
+}
+
+// OptionQuote is a quoted premium of an option.
+type OptionQuote struct {
+	Option *instrument.Option
+	Price  float64
+	// Weight scales the quote's pricing error in a calibration; 1 when
+	// zero. Weighting by 1/vega approximates an implied-volatility fit.
+	Weight float64
+}
+
+// HestonCalibration is the outcome of fitting Heston parameters to quotes.
+type HestonCalibration struct {
+	Params HestonParams
+	// RMSE is the root mean square of the weighted pricing errors.
+	RMSE       float64
+	Iterations int
+}
+
+// Calibrate fits V0, Kappa, Theta, Xi and Rho to quoted European option
+// prices by Levenberg-Marquardt least squares, starting from hp.Params or
+// DefaultHestonParams when those are zero. hp is not modified.
+func (hp *HestonPricer) Calibrate(ctx context.Context, quotes []OptionQuote) (HestonCalibration, error) {
+	if len(quotes) < 5 {
+		return HestonCalibration{}, fmt.Errorf("%w: %d quotes for 5 Heston parameters", ErrInvalidInput, len(quotes))
+	}
+	inputs := make([]Inputs, len(quotes))
+	for i, quote := range quotes {
+		opt, err := asEuropeanOption(quote.Option)
+		if err != nil {
+			return HestonCalibration{}, err
+		}
+		// The volatility only has to pass validation
+		if inputs[i], err = resolveInputs(hp.Market, opt, hp.RiskFreeRate, 0.2); err != nil {
+			return HestonCalibration{}, err
+		}
+		if err := finite("quote", quote.Price); err != nil {
+			return HestonCalibration{}, err
+		}
+	}
+
+	start := hp.Params
+	if start == (HestonParams{}) {
+		start = DefaultHestonParams
+	}
+	if err := start.Validate(); err != nil {
+		return HestonCalibration{}, err
+	}
+
+	residuals := func(x, r []float64) {
+		p := hestonFromUnconstrained(x)
+		for i, quote := range quotes {
+			w := quote.Weight
+			if w == 0 {
+				w = 1
+			}
+			r[i] = w * (hestonPrice(inputs[i], p, quote.Option.OptionType()) - quote.Price)
+		}
+	}
+	x, iters, err := levenbergMarquardt(ctx, residuals, len(quotes), hestonToUnconstrained(start), 200)
+	if err != nil {
+		return HestonCalibration{}, err
+	}
+
+	r := make([]float64, len(quotes))
+	residuals(x, r)
+	return HestonCalibration{
+		Params:     hestonFromUnconstrained(x),
+		RMSE:       math.Sqrt(sumSquares(r) / float64(len(r))),
+		Iterations: iters,
+	}, nil
+}
+
+// hestonToUnconstrained maps parameters to R^5 (logs and atanh) so that the
+// optimiser cannot leave the admissible region.
+func hestonToUnconstrained(p HestonParams) []float64 {
+	return []float64{math.Log(p.V0), math.Log(p.Kappa), math.Log(p.Theta), math.Log(p.Xi), math.Atanh(p.Rho)}
+}
+
+func hestonFromUnconstrained(x []float64) HestonParams {
+	return HestonParams{
+		V0:    math.Exp(x[0]),
+		Kappa: math.Exp(x[1]),
+		Theta: math.Exp(x[2]),
+		Xi:    math.Exp(x[3]),
+		Rho:   math.Tanh(x[4]),
+	}
+}
+
+func boolFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHestonPrice_Benchmark(t *testing.T) {
+	// Fang and Oosterlee (2008), COS method reference value
+	in := Inputs{Spot: 100, Strike: 100, Expiry: 1, Volatility: 0.1}
+	p := HestonParams{V0: 0.0175, Kappa: 1.5768, Theta: 0.0398, Xi: 0.5751, Rho: -0.5711}
+	assert.InDelta(t, 5.785155450, hestonPrice(in, p, instrument.Call), 1e-7)
+
+	// Put-call parity with rates and dividends
+	in = Inputs{Spot: 100, Strike: 110, Expiry: 0.5, RiskFreeRate: 0.03, DividendYield: 0.01, Volatility: 0.1}
+	call, put := hestonPrice(in, p, instrument.Call), hestonPrice(in, p, instrument.Put)
+	assert.InDelta(t, in.Spot*math.Exp(-0.005)-in.Strike*math.Exp(-0.015), call-put, 1e-10)
+}
+
+func TestHestonPrice_DeterministicVarianceIsBlackScholes(t *testing.T) {
+	// With v0 = theta and vanishing vol of variance, variance stays at theta
+	p := HestonParams{V0: 0.04, Kappa: 2, Theta: 0.04, Xi: 1e-4, Rho: 0}
+	for _, K := range []float64{70, 100, 130} {
+		in := Inputs{Spot: 100, Strike: K, Expiry: 2, RiskFreeRate: 0.05, DividendYield: 0.02, Volatility: 0.2}
+		assert.InDelta(t, bsPrice(in, instrument.Put), hestonPrice(in, p, instrument.Put), 1e-4, "K=%v", K)
+	}
+}
+
+func TestGaussLegendre(t *testing.T) {
+	nodes, weights := gaussLegendre(32)
+	// Exact for polynomials up to degree 63
+	var sum, x2, x10 float64
+	for i, x := range nodes {
+		sum += weights[i]
+		x2 += weights[i] * x * x
+		x10 += weights[i] * math.Pow(x, 10)
+	}
+	assert.InDelta(t, 2, sum, 1e-14)
+	assert.InDelta(t, 2.0/3, x2, 1e-14)
+	assert.InDelta(t, 2.0/11, x10, 1e-14)
+}
+
+func TestHestonPricer_PriceAndMonteCarloAgree(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100).SetVolatility("AAPL", 0.5)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(90), yearsFrom(now, 1), instrument.Put)
+	params := HestonParams{V0: 0.04, Kappa: 2, Theta: 0.05, Xi: 0.4, Rho: -0.7}
+
+	hp := NewHestonPricer(mkt, 0.03, params)
+	res, err := hp.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.Equal(t, ModelHeston, res.Model)
+	assert.InDelta(t, 0.2, res.Inputs.Volatility, 1e-12)
+
+	mc := NewMonteCarloPricer(mkt, 100000, 0.03, 0.2)
+	mc.Seed = 4
+	mc.Steps = 100
+	mc.Process = params.Process
+	sim, err := mc.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.InDelta(t, res.Value(), sim.Value(), 4*sim.StdErr+0.02)
+
+	g, err := hp.Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.Less(t, g.Delta, 0.0)
+	assert.Greater(t, g.Gamma, 0.0)
+	assert.Greater(t, g.Vega, 0.0)
+
+	hp.Params.Rho = 1
+	_, err = hp.Price(context.Background(), opt)
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+}
+
+func TestHestonPricer_CalibrateRecoversParameters(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	truth := HestonParams{V0: 0.03, Kappa: 1.2, Theta: 0.06, Xi: 0.6, Rho: -0.6}
+	generator := NewHestonPricer(mkt, 0.02, truth)
+
+	var quotes []OptionQuote
+	for _, years := range []float64{0.25, 0.5, 1, 2} {
+		for _, K := range []int64{80, 90, 100, 110, 120} {
+			optType := instrument.Call
+			if K < 100 {
+				optType = instrument.Put
+			}
+			opt := instrument.NewEuropeanOption("Q", underlying, decimal.NewFromInt(K), yearsFrom(now, years), optType)
+			res, err := generator.Price(context.Background(), opt)
+			assert.NoError(t, err)
+			quotes = append(quotes, OptionQuote{Option: opt, Price: res.Value()})
+		}
+	}
+
+	hp := NewHestonPricer(mkt, 0.02, HestonParams{})
+	cal, err := hp.Calibrate(context.Background(), quotes)
+	assert.NoError(t, err)
+	assert.Less(t, cal.RMSE, 1e-4)
+	assert.InDelta(t, truth.V0, cal.Params.V0, 1e-3)
+	assert.InDelta(t, truth.Kappa, cal.Params.Kappa, 0.1)
+	assert.InDelta(t, truth.Theta, cal.Params.Theta, 3e-3)
+	assert.InDelta(t, truth.Xi, cal.Params.Xi, 0.05)
+	assert.InDelta(t, truth.Rho, cal.Params.Rho, 0.03)
+	assert.Equal(t, HestonParams{}, hp.Params)
+
+	_, err = hp.Calibrate(context.Background(), quotes[:3])
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+}
@@ -0,0 +1,129 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+)
+
+// ErrArbitrageBounds is returned when a premium lies outside the no-arbitrage
+// bounds of the option, so no volatility can reproduce it.
+var ErrArbitrageBounds = errors.New("pricing: premium violates arbitrage bounds")
+
+const (
+	impliedVolTolerance = 1e-10
+	impliedVolMaxIter   = 100
+	impliedVolMin       = 1e-8
+	impliedVolMax       = 20.0
+)
+
+// ImpliedVolatility returns the Black-Scholes-Merton volatility at which an
+// option with inputs in is worth premium. in.Volatility, when positive, is
+// used as the starting guess. The solver runs Newton-Raphson on vega and
+// falls back to Brent's method when vega vanishes or a step leaves the
+// bracket, as happens deep in or out of the money.
+func ImpliedVolatility(premium float64, in Inputs, optType instrument.OptionType) (float64, error) {
+	guess := in.Volatility
+	in.Volatility = 1 // validated separately as the unknown
+	if err := in.Validate(); err != nil {
+		return 0, err
+	}
+	if err := finite("premium", premium); err != nil {
+		return 0, err
+	}
+	// Solve on the escrowed spot, where the price is lognormal
+	in = in.escrowed()
+
+	lower, upper := bsBounds(in, optType)
+	if premium <= lower || premium >= upper {
+		return 0, fmt.Errorf("%w: %g outside (%g, %g)", ErrArbitrageBounds, premium, lower, upper)
+	}
+
+	objective := func(sigma float64) float64 {
+		x := in
+		x.Volatility = sigma
+		return bsPrice(x, optType) - premium
+	}
+
+	// Newton-Raphson, tightening the bracket [lo, hi] as it goes
+	lo, hi := impliedVolMin, impliedVolMax
+	sigma := guess
+	if sigma <= lo || sigma >= hi || math.IsNaN(sigma) {
+		sigma = impliedVolGuess(in)
+	}
+	for i := 0; i < impliedVolMaxIter; i++ {
+		x := in
+		x.Volatility = sigma
+		diff := bsPrice(x, optType) - premium
+		if math.Abs(diff) < impliedVolTolerance {
+			return sigma, nil
+		}
+		if diff > 0 {
+			hi = sigma
+		} else {
+			lo = sigma
+		}
+
+		vega := bsGreeks(x, optType).Vega
+		if vega < 1e-12 {
+			break
+		}
+		next := sigma - diff/vega
+		if next <= lo || next >= hi {
+			break
+		}
+		if math.Abs(next-sigma) < impliedVolTolerance {
+			return next, nil
+		}
+		sigma = next
+	}
+
+	sigma, err := brent(objective, lo, hi, impliedVolTolerance, impliedVolMaxIter)
+	if err != nil {
+		return 0, fmt.Errorf("implied volatility for premium %g: %w", premium, err)
+	}
+	return sigma, nil
+}
+
+// ImpliedVolatility backs out the volatility implied by premium for a
+// European option, valued against the pricer's market snapshot.
+func (bs *BlackScholesPricer) ImpliedVolatility(ctx context.Context, inst instrument.Instrument, premium float64) (float64, error) {
+	opt, err := asEuropeanOption(inst)
+	if err != nil {
+		return 0, err
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	in, err := resolveInputs(bs.Market, opt, bs.RiskFreeRate, bs.Volatility)
+	if err != nil {
+		return 0, err
+	}
+	return ImpliedVolatility(premium, in, opt.OptionType())
+}
+
+// bsBounds returns the model-free lower and upper bounds of a European
+// option price.
+func bsBounds(in Inputs, optType instrument.OptionType) (float64, float64) {
+	fwdSpot := in.Spot * math.Exp(-in.DividendYield*in.Expiry)
+	pvStrike := in.Strike * math.Exp(-in.RiskFreeRate*in.Expiry)
+	if optType == instrument.Call {
+		return math.Max(fwdSpot-pvStrike, 0), fwdSpot
+	}
+	return math.Max(pvStrike-fwdSpot, 0), pvStrike
+}
+
+// impliedVolGuess is the Manaster-Koehler starting point, which keeps
+// Newton-Raphson in its region of convergence.
+func impliedVolGuess(in Inputs) float64 {
+	m := math.Log(in.Spot/in.Strike) + (in.RiskFreeRate-in.DividendYield)*in.Expiry
+	sigma := math.Sqrt(2 * math.Abs(m) / in.Expiry)
+	if sigma < 0.1 {
+		sigma = 0.1
+	}
+	return math.Min(sigma, impliedVolMax/2)
+}
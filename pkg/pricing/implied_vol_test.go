@@ -0,0 +1,82 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpliedVolatility_RoundTrip(t *testing.T) {
+	for _, typ := range []instrument.OptionType{instrument.Call, instrument.Put} {
+		for _, strike := range []float64{40, 80, 100, 120, 250} {
+			for _, sigma := range []float64{0.05, 0.2, 0.8, 2.5} {
+				in := Inputs{Spot: 100, Strike: strike, Expiry: 0.5, RiskFreeRate: 0.03, DividendYield: 0.01, Volatility: sigma}
+				premium := bsPrice(in, typ)
+				lower, _ := bsBounds(in, typ)
+				if premium-lower < 1e-9 {
+					// No vol information left in the premium
+					continue
+				}
+
+				t.Run(fmt.Sprintf("%s/K=%g/sigma=%g", typ, strike, sigma), func(t *testing.T) {
+					in.Volatility = 0 // no starting guess
+					got, err := ImpliedVolatility(premium, in, typ)
+					assert.NoError(t, err)
+					assert.InDelta(t, sigma, got, 1e-6)
+				})
+			}
+		}
+	}
+}
+
+func TestImpliedVolatility_ArbitrageBounds(t *testing.T) {
+	in := Inputs{Spot: 100, Strike: 90, Expiry: 1, RiskFreeRate: 0.05}
+
+	// Below intrinsic value of the call
+	_, err := ImpliedVolatility(5, in, instrument.Call)
+	assert.ErrorIs(t, err, ErrArbitrageBounds)
+
+	// A call cannot be worth more than the underlying
+	_, err = ImpliedVolatility(101, in, instrument.Call)
+	assert.ErrorIs(t, err, ErrArbitrageBounds)
+
+	// A put cannot be worth more than the discounted strike
+	_, err = ImpliedVolatility(90, in, instrument.Put)
+	assert.ErrorIs(t, err, ErrArbitrageBounds)
+
+	_, err = ImpliedVolatility(math.NaN(), in, instrument.Put)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	in.Expiry = -0.1
+	_, err = ImpliedVolatility(5, in, instrument.Put)
+	assert.ErrorIs(t, err, ErrExpired)
+}
+
+func TestBlackScholesPricer_ImpliedVolatility(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+
+	// 10.4506 is the BS value at sigma=20%
+	pricer := NewBlackScholesPricer(mkt, 0.05, 0.5)
+	sigma, err := pricer.ImpliedVolatility(context.Background(), opt, 10.4506)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.20, sigma, 1e-4)
+}
+
+func TestBrent(t *testing.T) {
+	root, err := brent(func(x float64) float64 { return x*x*x - 2*x - 5 }, 2, 3, 1e-12, 100)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0945514815423265, root, 1e-10)
+
+	_, err = brent(func(x float64) float64 { return x*x + 1 }, -1, 1, 1e-12, 100)
+	assert.Error(t, err)
+}
@@ -0,0 +1,156 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+)
+
+// Inputs holds the market and contract parameters an option was valued with.
+type Inputs struct {
+	Spot          float64
+	Strike        float64
+	Expiry        float64 // time to expiry in years
+	RiskFreeRate  float64
+	DividendYield float64
+	Volatility    float64
+	// Dividends are the discrete dividends going ex before expiry, on top
+	// of the continuous DividendYield.
+	Dividends []Dividend
+}
+
+// Validate checks the inputs are inside the domain of lognormal models.
+// An option at or past expiry yields ErrExpired; anything else out of range
+// yields an *InputError wrapping ErrInvalidInput.
+func (in Inputs) Validate() error {
+	if err := finite("expiry", in.Expiry); err != nil {
+		return err
+	}
+	if in.Expiry <= 0 {
+		return fmt.Errorf("%w: %.6f years to expiry", ErrExpired, in.Expiry)
+	}
+	if err := positive("spot", in.Spot); err != nil {
+		return err
+	}
+	if err := positive("strike", in.Strike); err != nil {
+		return err
+	}
+	if err := positive("volatility", in.Volatility); err != nil {
+		return err
+	}
+	if err := finite("rate", in.RiskFreeRate); err != nil {
+		return err
+	}
+	if err := finite("dividend yield", in.DividendYield); err != nil {
+		return err
+	}
+	return in.validateDividends()
+}
+
+// symbolOf returns the market symbol of inst, falling back to its ID.
+func symbolOf(inst instrument.Instrument) string {
+	if s, ok := inst.(interface{ Symbol() string }); ok {
+		return s.Symbol()
+	}
+	return inst.ID()
+}
+
+// yearFraction returns the ACT/365 year fraction between from and to.
+func yearFraction(from, to time.Time) float64 {
+	return to.Sub(from).Hours() / (24 * 365)
+}
+
+// valuationTime returns the snapshot's as-of time, or now if it has none.
+func valuationTime(mkt *market.Snapshot) time.Time {
+	if asOf := mkt.AsOf(); !asOf.IsZero() {
+		return asOf
+	}
+	return time.Now()
+}
+
+// resolveInputs looks up the spot of the option's underlying in mkt and
+// combines it with the contract terms. The snapshot's rate and volatility
+// take precedence over the pricer defaults r and sigma when present, and a
+// volatility surface recorded for the underlying over a flat volatility.
+// The resolved inputs are validated before they are returned.
+func resolveInputs(mkt *market.Snapshot, opt *instrument.Option, r, sigma float64) (Inputs, error) {
+	in, err := marketInputs(mkt, opt, opt.Strike().InexactFloat64(), r, sigma)
+	if err != nil {
+		return Inputs{}, err
+	}
+	if err := in.Validate(); err != nil {
+		return Inputs{}, err
+	}
+	return in, nil
+}
+
+// derivative is a contract on a single underlying that expires.
+type derivative interface {
+	ID() string
+	Underlying() instrument.Instrument
+	Expiry() time.Time
+}
+
+// marketInputs is resolveInputs without validation, for callers that adjust
+// the inputs first or price contracts other than vanilla options.
+func marketInputs(mkt *market.Snapshot, opt derivative, strike float64, r, sigma float64) (Inputs, error) {
+	in, err := quotedInputs(mkt, opt, strike, r, sigma)
+	if err != nil {
+		return Inputs{}, err
+	}
+	symbol := symbolOf(opt.Underlying())
+	if surface, ok := mkt.VolSurface(symbol); ok && in.Expiry > 0 && !math.IsInf(in.Expiry, 0) {
+		vol, err := surfaceVolatility(surface, symbol, in.Spot, in.Strike, in.Expiry, in.RiskFreeRate, in.DividendYield)
+		if err != nil {
+			return Inputs{}, err
+		}
+		in.Volatility = vol
+	}
+	return in, nil
+}
+
+// quotedInputs is marketInputs without the volatility surface, for models
+// whose volatility is not a Black-Scholes one.
+func quotedInputs(mkt *market.Snapshot, opt derivative, strike float64, r, sigma float64) (Inputs, error) {
+	if mkt == nil {
+		return Inputs{}, fmt.Errorf("%w: no snapshot to price %s", ErrMissingMarketData, opt.ID())
+	}
+	symbol := symbolOf(opt.Underlying())
+	spot, ok := mkt.Spot(symbol)
+	if !ok {
+		return Inputs{}, fmt.Errorf("%w: no spot for %s", ErrMissingMarketData, symbol)
+	}
+	if rate, ok := mkt.RiskFreeRate(); ok {
+		r = rate
+	}
+	if vol, ok := mkt.Volatility(symbol); ok {
+		sigma = vol
+	}
+	asOf := valuationTime(mkt)
+	return Inputs{
+		Spot:          spot,
+		Strike:        strike,
+		Expiry:        yearFraction(asOf, opt.Expiry()),
+		RiskFreeRate:  r,
+		DividendYield: mkt.DividendYield(symbol),
+		Volatility:    sigma,
+		Dividends:     dividendSchedule(opt.Underlying(), asOf, opt.Expiry()),
+	}, nil
+}
+
+// surfaceVolatility looks up the volatility of an option struck at strike
+// on surface. Contracts without a positive strike, such as Asian options
+// struck on the average, take the at-the-money forward volatility.
+func surfaceVolatility(surface market.VolSurface, symbol string, spot, strike, expiry, r, q float64) (float64, error) {
+	if !(strike > 0) || math.IsInf(strike, 0) {
+		strike = spot * math.Exp((r-q)*expiry)
+	}
+	vol, err := surface.Volatility(expiry, strike)
+	if err != nil {
+		return 0, fmt.Errorf("%w: volatility of %s: %w", ErrMissingMarketData, symbol, err)
+	}
+	return vol, nil
+}
@@ -0,0 +1,60 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// skewSurface is a volatility surface that falls linearly in strike.
+type skewSurface struct{}
+
+func (skewSurface) Volatility(expiry, strike float64) (float64, error) {
+	if strike > 1000 {
+		return 0, errors.New("strike off the surface")
+	}
+	return 0.3 - 0.001*strike + 0.01*expiry, nil
+}
+
+func TestMarketInputs_VolSurface(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100).SetVolatility("AAPL", 0.5).
+		SetDividendYield("AAPL", 0.01).SetVolSurface("AAPL", skewSurface{})
+	aapl := instrument.NewEquity("AAPL", "USD", "AAPL")
+
+	// The surface is read at the option's strike, ahead of the flat quote
+	opt := instrument.NewEuropeanOption("C", aapl, decimal.NewFromInt(90), yearsFrom(now, 1), instrument.Call)
+	in, err := resolveInputs(mkt, opt, 0.05, 0.2)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.3-0.09+0.01, in.Volatility, 1e-12)
+
+	// and at the money forward without a strike
+	in, err = marketInputs(mkt, opt, 0, 0.05, 0.2)
+	assert.NoError(t, err)
+	forward := 100 * math.Exp(0.04)
+	assert.InDelta(t, 0.3-0.001*forward+0.01, in.Volatility, 1e-9)
+
+	far := instrument.NewEuropeanOption("C", aapl, decimal.NewFromInt(2000), yearsFrom(now, 1), instrument.Call)
+	_, err = resolveInputs(mkt, far, 0.05, 0.2)
+	assert.ErrorIs(t, err, ErrMissingMarketData)
+
+	// Bachelier volatilities are normal, so the surface does not apply
+	_, _, err = NewBachelierPricer(mkt, 0.05, 5).resolve(context.Background(), far)
+	assert.NoError(t, err)
+
+	// Each asset of a basket takes its at-the-money forward volatility
+	mkt.SetSpot("MSFT", 200).SetVolatility("MSFT", 0.25).SetCorrelation("AAPL", "MSFT", 0.5)
+	basket := instrument.NewBasketOption("B", []instrument.Instrument{aapl, instrument.NewEquity("MSFT", "USD", "MSFT")},
+		[]decimal.Decimal{decimal.NewFromInt(1), decimal.NewFromInt(1)}, decimal.NewFromInt(300), yearsFrom(now, 1), instrument.Call)
+	assets, err := resolveMultiAsset(mkt, basket, 0.05, 0.2)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.3-0.001*forward+0.01, assets.vols[0], 1e-9)
+	assert.Equal(t, 0.25, assets.vols[1])
+}
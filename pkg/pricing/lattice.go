@@ -0,0 +1,302 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+)
+
+// LatticeMethod selects the tree used by LatticePricer.
+type LatticeMethod string
+
+const (
+	// BinomialCRR is the Cox-Ross-Rubinstein binomial tree.
+	BinomialCRR LatticeMethod = "binomial-crr"
+	// Trinomial is the Boyle trinomial tree with log-spacing sigma*sqrt(3dt).
+	Trinomial LatticeMethod = "trinomial"
+)
+
+// DefaultLatticeSteps is the number of time steps used when Steps is zero.
+const DefaultLatticeSteps = 200
+
+// LatticePricer values European and American options on a recombining tree.
+// RiskFreeRate and Volatility are defaults used when the market snapshot
+// does not quote them.
+type LatticePricer struct {
+	Market       *market.Snapshot
+	Method       LatticeMethod
+	Steps        int
+	RiskFreeRate float64
+	Volatility   float64
+	// Richardson extrapolates the price from Steps and Steps/2 trees,
+	// cancelling the leading 1/N discretisation error.
+	Richardson bool
+}
+
+// NewLatticePricer creates a new lattice pricer valuing against mkt.
+func NewLatticePricer(mkt *market.Snapshot, method LatticeMethod, steps int, r, sigma float64) *LatticePricer {
+	return &LatticePricer{
+		Market:       mkt,
+		Method:       method,
+		Steps:        steps,
+		RiskFreeRate: r,
+		Volatility:   sigma,
+	}
+}
+
+var (
+	_ Pricer           = (*LatticePricer)(nil)
+	_ GreeksCalculator = (*LatticePricer)(nil)
+)
+
+// latticeResult is the root value of a tree together with the Greeks read
+// off its first nodes.
+type latticeResult struct {
+	price float64
+	delta float64
+	gamma float64
+	theta float64
+}
+
+// Price values a European or American option by backward induction.
+func (lp *LatticePricer) Price(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+	opt, in, err := lp.resolve(inst)
+	if err != nil {
+		return PricingResult{}, err
+	}
+
+	res, err := lp.build(ctx, in, opt, lp.steps())
+	if err != nil {
+		return PricingResult{}, err
+	}
+	price := res.price
+	if lp.Richardson {
+		half, err := lp.build(ctx, in, opt, lp.steps()/2)
+		if err != nil {
+			return PricingResult{}, err
+		}
+		price = 2*res.price - half.price
+	}
+
+	return PricingResult{
+		Price:  money.NewFromFloat(price, opt.Currency()),
+		Model:  string(lp.method()),
+		Inputs: in,
+		Diagnostics: map[string]float64{
+			"steps": float64(lp.steps()),
+		},
+	}, nil
+}
+
+// Greeks returns delta, gamma and theta read off the tree and vega and rho
+// by revaluing on bumped trees. Vanna, Volga and Charm are not reported.
+func (lp *LatticePricer) Greeks(ctx context.Context, inst instrument.Instrument) (Greeks, error) {
+	opt, in, err := lp.resolve(inst)
+	if err != nil {
+		return Greeks{}, err
+	}
+
+	res, err := lp.build(ctx, in, opt, lp.steps())
+	if err != nil {
+		return Greeks{}, err
+	}
+
+	revalue := func(dv, dr float64) (float64, error) {
+		x := in
+		x.Volatility += dv
+		x.RiskFreeRate += dr
+		r, err := lp.build(ctx, x, opt, lp.steps())
+		return r.price, err
+	}
+	dv, dr := DefaultBumpSizes.Volatility, DefaultBumpSizes.Rate
+	vUp, err := revalue(dv, 0)
+	if err != nil {
+		return Greeks{}, err
+	}
+	vDown, err := revalue(-dv, 0)
+	if err != nil {
+		return Greeks{}, err
+	}
+	rUp, err := revalue(0, dr)
+	if err != nil {
+		return Greeks{}, err
+	}
+	rDown, err := revalue(0, -dr)
+	if err != nil {
+		return Greeks{}, err
+	}
+
+	return Greeks{
+		Delta: res.delta,
+		Gamma: res.gamma,
+		Theta: res.theta,
+		Vega:  (vUp - vDown) / (2 * dv),
+		Rho:   (rUp - rDown) / (2 * dr),
+	}, nil
+}
+
+func (lp *LatticePricer) resolve(inst instrument.Instrument) (*instrument.Option, Inputs, error) {
+	opt, err := asOption(inst)
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	if opt.Style() == instrument.Bermudan {
+		return nil, Inputs{}, fmt.Errorf("%w: %s exercise of %s", ErrUnsupportedInstrument, opt.Style(), opt.ID())
+	}
+	in, err := resolveInputs(lp.Market, opt, lp.RiskFreeRate, lp.Volatility)
+	if err != nil {
+		return nil, Inputs{}, err
+	}
+	if lp.Steps != 0 && lp.Steps < 4 {
+		return nil, Inputs{}, &InputError{Field: "steps", Value: float64(lp.Steps)}
+	}
+	return opt, in, nil
+}
+
+func (lp *LatticePricer) steps() int {
+	if lp.Steps == 0 {
+		return DefaultLatticeSteps
+	}
+	return lp.Steps
+}
+
+func (lp *LatticePricer) method() LatticeMethod {
+	if lp.Method == "" {
+		return BinomialCRR
+	}
+	return lp.Method
+}
+
+func (lp *LatticePricer) build(ctx context.Context, in Inputs, opt *instrument.Option, steps int) (latticeResult, error) {
+	switch lp.method() {
+	case BinomialCRR:
+		return binomialTree(ctx, in, opt.OptionType(), opt.Style(), steps)
+	case Trinomial:
+		return trinomialTree(ctx, in, opt.OptionType(), opt.Style(), steps)
+	default:
+		return latticeResult{}, fmt.Errorf("pricing: unknown lattice method %q", lp.Method)
+	}
+}
+
+// latticeDividends returns the escrowed spot at the root of a tree with the
+// given steps, and for each step the present value of the cash dividends
+// still to go ex and the fraction of the spot the proportional ones keep.
+// Trees are built on the escrowed spot; adding the dividends back at each
+// node gives the cum-dividend spot that exercise decisions see, so calls
+// are exercised early ahead of large dividends.
+func latticeDividends(in Inputs, dt float64, steps int) (float64, []float64, []float64) {
+	cash := make([]float64, steps+1)
+	factor := make([]float64, steps+1)
+	for i := range cash {
+		cash[i], factor[i] = in.dividendAdjustment(float64(i) * dt)
+	}
+	return in.escrowed().Spot, cash, factor
+}
+
+// binomialTree runs backward induction on a CRR tree with the given steps.
+func binomialTree(ctx context.Context, in Inputs, optType instrument.OptionType, style instrument.ExerciseStyle, steps int) (latticeResult, error) {
+	dt := in.Expiry / float64(steps)
+	u := math.Exp(in.Volatility * math.Sqrt(dt))
+	d := 1 / u
+	p := (math.Exp((in.RiskFreeRate-in.DividendYield)*dt) - d) / (u - d)
+	if p <= 0 || p >= 1 {
+		return latticeResult{}, &InputError{Field: "steps", Value: float64(steps)}
+	}
+	disc := math.Exp(-in.RiskFreeRate * dt)
+	american := style == instrument.American
+
+	x0, cash, factor := latticeDividends(in, dt, steps)
+	spot := func(i, j int) float64 {
+		return (x0*math.Pow(u, float64(2*j-i)) + cash[i]) / factor[i]
+	}
+
+	values := make([]float64, steps+1)
+	for j := 0; j <= steps; j++ {
+		values[j] = vanillaPayoff(optType, spot(steps, j), in.Strike)
+	}
+
+	var res latticeResult
+	for i := steps - 1; i >= 0; i-- {
+		if i%64 == 0 && ctx.Err() != nil {
+			return latticeResult{}, ctx.Err()
+		}
+		for j := 0; j <= i; j++ {
+			v := disc * (p*values[j+1] + (1-p)*values[j])
+			if american {
+				v = math.Max(v, vanillaPayoff(optType, spot(i, j), in.Strike))
+			}
+			values[j] = v
+		}
+
+		switch i {
+		case 2:
+			dUp := (values[2] - values[1]) / (spot(2, 2) - spot(2, 1))
+			dDown := (values[1] - values[0]) / (spot(2, 1) - spot(2, 0))
+			res.gamma = (dUp - dDown) / (0.5 * (spot(2, 2) - spot(2, 0)))
+			res.theta = values[1]
+		case 1:
+			res.delta = (values[1] - values[0]) / (spot(1, 1) - spot(1, 0))
+		}
+	}
+	res.price = values[0]
+	res.theta = (res.theta - res.price) / (2 * dt)
+	return res, nil
+}
+
+// trinomialTree runs backward induction on a trinomial tree with the given
+// steps. Node k at any step sits at X0*exp(k*dx) on the escrowed spot.
+func trinomialTree(ctx context.Context, in Inputs, optType instrument.OptionType, style instrument.ExerciseStyle, steps int) (latticeResult, error) {
+	dt := in.Expiry / float64(steps)
+	sigma := in.Volatility
+	dx := sigma * math.Sqrt(3*dt)
+	nu := in.RiskFreeRate - in.DividendYield - 0.5*sigma*sigma
+	tilt := nu * math.Sqrt(dt/(12*sigma*sigma))
+	pu, pm, pd := 1.0/6+tilt, 2.0/3, 1.0/6-tilt
+	if pu <= 0 || pd <= 0 {
+		return latticeResult{}, &InputError{Field: "steps", Value: float64(steps)}
+	}
+	disc := math.Exp(-in.RiskFreeRate * dt)
+	american := style == instrument.American
+
+	// values[k+i] holds node k at step i
+	x0, cash, factor := latticeDividends(in, dt, steps)
+	spot := func(i, k int) float64 {
+		return (x0*math.Exp(float64(k)*dx) + cash[i]) / factor[i]
+	}
+
+	values := make([]float64, 2*steps+1)
+	for k := -steps; k <= steps; k++ {
+		values[k+steps] = vanillaPayoff(optType, spot(steps, k), in.Strike)
+	}
+
+	var res latticeResult
+	for i := steps - 1; i >= 0; i-- {
+		if i%64 == 0 && ctx.Err() != nil {
+			return latticeResult{}, ctx.Err()
+		}
+		for k := -i; k <= i; k++ {
+			// Children of node k at step i+1 live at offsets k+i+1 +/- 1
+			c := k + i + 1
+			v := disc * (pu*values[c+1] + pm*values[c] + pd*values[c-1])
+			if american {
+				v = math.Max(v, vanillaPayoff(optType, spot(i, k), in.Strike))
+			}
+			values[k+i] = v
+		}
+
+		if i == 1 {
+			dUp := (values[2] - values[1]) / (spot(1, 1) - spot(1, 0))
+			dDown := (values[1] - values[0]) / (spot(1, 0) - spot(1, -1))
+			res.delta = (values[2] - values[0]) / (spot(1, 1) - spot(1, -1))
+			res.gamma = (dUp - dDown) / (0.5 * (spot(1, 1) - spot(1, -1)))
+			res.theta = values[1]
+		}
+	}
+	res.price = values[0]
+	res.theta = (res.theta - res.price) / dt
+	return res, nil
+}
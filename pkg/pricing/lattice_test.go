@@ -0,0 +1,103 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatticePricer_EuropeanConvergesToBlackScholes(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100).SetDividendYield("AAPL", 0.02)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	expiry := yearsFrom(now, 1)
+
+	bs := NewBlackScholesPricer(mkt, 0.05, 0.2)
+	for _, method := range []LatticeMethod{BinomialCRR, Trinomial} {
+		for _, typ := range []instrument.OptionType{instrument.Call, instrument.Put} {
+			opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(105), expiry, typ)
+			want, err := bs.Price(context.Background(), opt)
+			assert.NoError(t, err)
+
+			lp := NewLatticePricer(mkt, method, 500, 0.05, 0.2)
+			got, err := lp.Price(context.Background(), opt)
+			assert.NoError(t, err)
+			assert.InDelta(t, want.Value(), got.Value(), 0.01, "%s %s", method, typ)
+			assert.Equal(t, string(method), got.Model)
+
+			wantGreeks, _ := bs.Greeks(context.Background(), opt)
+			gotGreeks, err := lp.Greeks(context.Background(), opt)
+			assert.NoError(t, err)
+			assert.InDelta(t, wantGreeks.Delta, gotGreeks.Delta, 0.005, "%s %s", method, typ)
+			assert.InDelta(t, wantGreeks.Gamma, gotGreeks.Gamma, 0.001, "%s %s", method, typ)
+			assert.InDelta(t, wantGreeks.Theta, gotGreeks.Theta, 0.05, "%s %s", method, typ)
+			// Bumping vol moves nodes relative to the strike, so vega is coarser
+			assert.InDelta(t, wantGreeks.Vega, gotGreeks.Vega, 0.5, "%s %s", method, typ)
+			assert.InDelta(t, wantGreeks.Rho, gotGreeks.Rho, 0.1, "%s %s", method, typ)
+		}
+	}
+}
+
+func TestLatticePricer_AmericanPut(t *testing.T) {
+	// Hull: S=50, K=50, r=10%, sigma=40%, T=5 months. American put ~4.28
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 50)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	expiry := yearsFrom(now, 5.0/12)
+	american := instrument.NewAmericanOption("AP", underlying, decimal.NewFromInt(50), expiry, instrument.Put)
+	european := instrument.NewEuropeanOption("EP", underlying, decimal.NewFromInt(50), expiry, instrument.Put)
+
+	for _, method := range []LatticeMethod{BinomialCRR, Trinomial} {
+		lp := NewLatticePricer(mkt, method, 1000, 0.10, 0.40)
+		amer, err := lp.Price(context.Background(), american)
+		assert.NoError(t, err)
+		assert.InDelta(t, 4.28, amer.Value(), 0.01, method)
+
+		euro, err := lp.Price(context.Background(), european)
+		assert.NoError(t, err)
+		assert.Greater(t, amer.Value(), euro.Value(), "early exercise premium")
+
+		// Richardson extrapolation with few steps lands close to the fine tree
+		coarse := NewLatticePricer(mkt, method, 100, 0.10, 0.40)
+		coarse.Richardson = true
+		extrapolated, err := coarse.Price(context.Background(), american)
+		assert.NoError(t, err)
+		assert.InDelta(t, amer.Value(), extrapolated.Value(), 0.01, method)
+	}
+}
+
+func TestLatticePricer_AmericanCallWithoutDividends(t *testing.T) {
+	// Early exercise of a call on a non-dividend payer is never optimal
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	expiry := yearsFrom(now, 1)
+	american := instrument.NewAmericanOption("AC", underlying, decimal.NewFromInt(100), expiry, instrument.Call)
+	european := instrument.NewEuropeanOption("EC", underlying, decimal.NewFromInt(100), expiry, instrument.Call)
+
+	lp := NewLatticePricer(mkt, BinomialCRR, 300, 0.05, 0.2)
+	amer, err := lp.Price(context.Background(), american)
+	assert.NoError(t, err)
+	euro, err := lp.Price(context.Background(), european)
+	assert.NoError(t, err)
+	assert.InDelta(t, euro.Value(), amer.Value(), 1e-9)
+}
+
+func TestEuropeanOnlyPricersRejectAmerican(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	opt := instrument.NewAmericanOption("AP", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Put)
+
+	_, err := NewBlackScholesPricer(mkt, 0.05, 0.2).Price(context.Background(), opt)
+	assert.ErrorIs(t, err, ErrUnsupportedInstrument)
+
+	lp := NewLatticePricer(mkt, BinomialCRR, 2, 0.05, 0.2)
+	_, err = lp.Price(context.Background(), opt)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -0,0 +1,210 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+)
+
+// errSingular is returned when a linear system has no unique solution.
+var errSingular = errors.New("pricing: singular matrix")
+
+// solveLinear solves A x = b by Gaussian elimination with partial pivoting.
+// A and b are left untouched.
+func solveLinear(A [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n+1)
+		copy(m[i], A[i])
+		m[i][n] = b[i]
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1e-300 {
+			return nil, errSingular
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for row := col + 1; row < n; row++ {
+			f := m[row][col] / m[col][col]
+			for k := col; k <= n; k++ {
+				m[row][k] -= f * m[col][k]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		s := m[i][n]
+		for k := i + 1; k < n; k++ {
+			s -= m[i][k] * x[k]
+		}
+		x[i] = s / m[i][i]
+	}
+	return x, nil
+}
+
+// leastSquares returns the coefficients beta minimising |X beta - y|^2,
+// where each row of X holds the regressors of one observation.
+func leastSquares(X [][]float64, y []float64) ([]float64, error) {
+	if len(X) == 0 {
+		return nil, errSingular
+	}
+	p := len(X[0])
+	xtx := make([][]float64, p)
+	for i := range xtx {
+		xtx[i] = make([]float64, p)
+	}
+	xty := make([]float64, p)
+	for r, row := range X {
+		for i := 0; i < p; i++ {
+			xty[i] += row[i] * y[r]
+			for j := i; j < p; j++ {
+				xtx[i][j] += row[i] * row[j]
+			}
+		}
+	}
+	for i := 0; i < p; i++ {
+		for j := 0; j < i; j++ {
+			xtx[i][j] = xtx[j][i]
+		}
+	}
+	return solveLinear(xtx, xty)
+}
+
+// errNotPSD is returned when a matrix is not positive semidefinite.
+var errNotPSD = errors.New("pricing: matrix is not positive semidefinite")
+
+// cholesky returns the lower-triangular L with L L^T = A for a symmetric
+// positive semidefinite A. Pivots within tol of zero are treated as zero,
+// so that singular correlation matrices, such as those of perfectly
+// correlated assets, factor too.
+func cholesky(A [][]float64, tol float64) ([][]float64, error) {
+	n := len(A)
+	L := make([][]float64, n)
+	for i := range L {
+		L[i] = make([]float64, n)
+	}
+	for j := 0; j < n; j++ {
+		d := A[j][j]
+		for k := 0; k < j; k++ {
+			d -= L[j][k] * L[j][k]
+		}
+		if d < -tol {
+			return nil, errNotPSD
+		}
+		if d <= tol {
+			// The column lies in the span of the previous ones
+			for i := j + 1; i < n; i++ {
+				s := A[i][j]
+				for k := 0; k < j; k++ {
+					s -= L[i][k] * L[j][k]
+				}
+				if math.Abs(s) > math.Sqrt(tol) {
+					return nil, errNotPSD
+				}
+			}
+			continue
+		}
+		L[j][j] = math.Sqrt(d)
+		for i := j + 1; i < n; i++ {
+			s := A[i][j]
+			for k := 0; k < j; k++ {
+				s -= L[i][k] * L[j][k]
+			}
+			L[i][j] = s / L[j][j]
+		}
+	}
+	return L, nil
+}
+
+// symmetricEigen returns the eigenvalues of the symmetric matrix A and the
+// matching eigenvectors as the columns of V, by cyclic Jacobi rotations.
+// A is left untouched.
+func symmetricEigen(A [][]float64) ([]float64, [][]float64) {
+	n := len(A)
+	a := make([][]float64, n)
+	V := make([][]float64, n)
+	for i := range a {
+		a[i] = append([]float64(nil), A[i]...)
+		V[i] = make([]float64, n)
+		V[i][i] = 1
+	}
+
+	for sweep := 0; sweep < 100; sweep++ {
+		off, norm := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				norm += a[i][j] * a[i][j]
+				if i != j {
+					off += a[i][j] * a[i][j]
+				}
+			}
+		}
+		if off <= 1e-30*norm {
+			break
+		}
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				// Rotate by the angle that zeroes a[p][q]
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < n; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := 0; k < n; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := V[k][p], V[k][q]
+					V[k][p], V[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = a[i][i]
+	}
+	return values, V
+}
+
+// solveTridiagonal solves the tridiagonal system with sub-diagonal lower,
+// diagonal diag and super-diagonal upper for rhs into x, using the Thomas
+// algorithm. lower[0] and upper[n-1] are ignored. The system must be
+// diagonally dominant, as implicit finite-difference schemes are; scratch
+// must have room for n values.
+func solveTridiagonal(lower, diag, upper, rhs, x, scratch []float64) error {
+	n := len(diag)
+	if diag[0] == 0 {
+		return errSingular
+	}
+	beta := diag[0]
+	x[0] = rhs[0] / beta
+	for i := 1; i < n; i++ {
+		scratch[i] = upper[i-1] / beta
+		beta = diag[i] - lower[i]*scratch[i]
+		if beta == 0 {
+			return errSingular
+		}
+		x[i] = (rhs[i] - lower[i]*x[i-1]) / beta
+	}
+	for i := n - 2; i >= 0; i-- {
+		x[i] -= scratch[i+1] * x[i+1]
+	}
+	return nil
+}
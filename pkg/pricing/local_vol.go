@@ -0,0 +1,140 @@
+package pricing
+
+import (
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/process"
+)
+
+const (
+	// localVolTimes and localVolMoneyness are the number of times and of
+	// log-moneyness points the local volatility is tabulated on.
+	localVolTimes     = 60
+	localVolMoneyness = 121
+	// localVolStdDevs is how many standard deviations of the log spot at
+	// the horizon the table spans either side of the forward.
+	localVolStdDevs = 5
+	// localVolFloor and localVolCap bound the tabulated volatilities.
+	localVolFloor = 0.01
+	localVolCap   = 5
+)
+
+// LocalVolatility is the Dupire local volatility implied by a volatility
+// surface: the volatility function sigma(t, S) under which the model
+// reprices every European option on the surface. With total implied
+// variance w(y, T) at log-moneyness y = ln(K/F_T), Gatheral's form of
+// Dupire's equation gives
+//
+//	sigma^2 = w_T / (1 - y w_y/w + 1/4 (-1/4 - 1/w + y^2/w^2) w_y^2 + 1/2 w_yy)
+//
+// The derivatives are taken by finite differences on a table of times up
+// to a horizon and of log-moneyness around the forward, between which the
+// volatility is interpolated bilinearly; beyond the table it is held flat.
+// Where the surface admits arbitrage the formula breaks down, and the
+// implied volatility is used instead.
+type LocalVolatility struct {
+	spot  float64
+	drift float64
+	dt    float64
+	yMin  float64
+	dy    float64
+	vols  [][]float64 // vols[i][j] at time (i+1) dt and log-moneyness yMin + j dy
+}
+
+// NewLocalVolatility derives the local volatility of an underlying at spot,
+// with rate r and dividend yield q, from its implied volatility surface
+// over the next horizon years. It fails if the surface cannot be queried
+// across the table.
+func NewLocalVolatility(surface market.VolSurface, spot, r, q, horizon float64) (*LocalVolatility, error) {
+	if err := positive("spot", spot); err != nil {
+		return nil, err
+	}
+	if err := finite("rate", r); err != nil {
+		return nil, err
+	}
+	if err := finite("dividend yield", q); err != nil {
+		return nil, err
+	}
+	if err := positive("horizon", horizon); err != nil {
+		return nil, err
+	}
+	lv := &LocalVolatility{spot: spot, drift: r - q, dt: horizon / localVolTimes}
+
+	// Size the table by the at-the-money volatility at the horizon
+	atm, err := surface.Volatility(horizon, lv.forward(horizon))
+	if err != nil {
+		return nil, err
+	}
+	if err := positive("volatility", atm); err != nil {
+		return nil, err
+	}
+	width := math.Max(localVolStdDevs*atm*math.Sqrt(horizon), 0.5)
+	lv.yMin = -width
+	lv.dy = 2 * width / (localVolMoneyness - 1)
+
+	// Total variance at time t and log-moneyness y
+	variance := func(t, y float64) (float64, error) {
+		vol, err := surface.Volatility(t, lv.forward(t)*math.Exp(y))
+		return vol * vol * t, err
+	}
+	lv.vols = make([][]float64, localVolTimes)
+	for i := range lv.vols {
+		t := float64(i+1) * lv.dt
+		ht := lv.dt / 2
+		lv.vols[i] = make([]float64, localVolMoneyness)
+		for j := range lv.vols[i] {
+			y := lv.yMin + float64(j)*lv.dy
+			var w [5]float64 // at (t, y), (t±ht, y), (t, y±dy)
+			for k, p := range [5][2]float64{{t, y}, {t + ht, y}, {t - ht, y}, {t, y + lv.dy}, {t, y - lv.dy}} {
+				if w[k], err = variance(p[0], p[1]); err != nil {
+					return nil, err
+				}
+			}
+			lv.vols[i][j] = dupire(y, w[0], (w[1]-w[2])/(2*ht), (w[3]-w[4])/(2*lv.dy), (w[3]-2*w[0]+w[4])/(lv.dy*lv.dy), t)
+		}
+	}
+	return lv, nil
+}
+
+// dupire returns the local volatility at log-moneyness y and time t from
+// the total variance w and its derivatives, falling back on the implied
+// volatility where they admit arbitrage.
+func dupire(y, w, wT, wy, wyy, t float64) float64 {
+	den := 1 - y*wy/w + 0.25*(-0.25-1/w+y*y/(w*w))*wy*wy + 0.5*wyy
+	sigma := math.Sqrt(w / t)
+	if wT > 0 && den > 0 {
+		sigma = math.Sqrt(wT / den)
+	}
+	if math.IsNaN(sigma) {
+		sigma = localVolFloor
+	}
+	return math.Min(math.Max(sigma, localVolFloor), localVolCap)
+}
+
+func (lv *LocalVolatility) forward(t float64) float64 {
+	return lv.spot * math.Exp(lv.drift*t)
+}
+
+// Vol returns the local volatility at time t and spot s. It is a
+// process.LocalVolFunc.
+func (lv *LocalVolatility) Vol(t, s float64) float64 {
+	if !(s > 0) {
+		s = math.SmallestNonzeroFloat64
+	}
+	// Fractional indexes into the table, held at its edges
+	x := math.Min(math.Max(t/lv.dt-1, 0), localVolTimes-1)
+	y := math.Min(math.Max((math.Log(s/lv.forward(t))-lv.yMin)/lv.dy, 0), localVolMoneyness-1)
+	i := min(int(x), localVolTimes-2)
+	j := min(int(y), localVolMoneyness-2)
+	a, b := x-float64(i), y-float64(j)
+	return (1-a)*((1-b)*lv.vols[i][j]+b*lv.vols[i][j+1]) + a*((1-b)*lv.vols[i+1][j]+b*lv.vols[i+1][j+1])
+}
+
+// Process returns the local volatility process for the spot, rate and
+// dividend yield in in, for use as MonteCarloPricer.Process. The
+// volatility stays that of the surface when in.Volatility is bumped, so
+// bump-and-revalue vega is zero.
+func (lv *LocalVolatility) Process(in Inputs) process.Process {
+	return process.LocalVol{S0: in.Spot, Drift: in.RiskFreeRate - in.DividendYield, Vol: lv.Vol}
+}
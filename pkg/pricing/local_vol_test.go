@@ -0,0 +1,113 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// flatSurface quotes the same implied volatility everywhere.
+type flatSurface float64
+
+func (f flatSurface) Volatility(_, _ float64) (float64, error) {
+	return float64(f), nil
+}
+
+func TestLocalVolatility_FlatSurface(t *testing.T) {
+	lv, err := NewLocalVolatility(flatSurface(0.25), 100, 0.05, 0.02, 2)
+	assert.NoError(t, err)
+	for _, T := range []float64{0, 0.01, 0.5, 1.3, 2, 5} {
+		for _, S := range []float64{1, 60, 100, 170, 1e4} {
+			assert.InDelta(t, 0.25, lv.Vol(T, S), 1e-6, "at %g, %g", T, S)
+		}
+	}
+
+	_, err = NewLocalVolatility(&SSVISurface{Spot: 100}, 100, 0.05, 0, 1)
+	assert.ErrorIs(t, err, ErrInvalidInput, "a surface without expiries")
+	_, err = NewLocalVolatility(flatSurface(0.25), 100, 0.05, 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+// skewedSSVI returns an arbitrage-free SSVI surface with a downward skew.
+func skewedSSVI(spot, r, q float64) *SSVISurface {
+	return &SSVISurface{
+		Params:        SSVIParams{Rho: -0.7, Eta: 1, Gamma: 0.4},
+		Spot:          spot,
+		RiskFreeRate:  r,
+		DividendYield: q,
+		Expiries:      []float64{0.25, 0.5, 1, 2},
+		ATMVariance:   []float64{0.012, 0.022, 0.04, 0.075},
+	}
+}
+
+func TestLocalVolatility_RepricesVanillas(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	surface := skewedSSVI(100, 0.04, 0.01)
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100).SetRiskFreeRate(0.04).
+		SetDividendYield("TEST", 0.01).SetVolSurface("TEST", surface)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	lv, err := NewLocalVolatility(surface, 100, 0.04, 0.01, 1)
+	assert.NoError(t, err)
+
+	// The skew makes the local volatility fall with the spot
+	assert.Greater(t, lv.Vol(0.5, 80), lv.Vol(0.5, 100))
+	assert.Greater(t, lv.Vol(0.5, 100), lv.Vol(0.5, 120))
+
+	bs := NewBlackScholesPricer(mkt, 0, 0.2)
+	mc := NewMonteCarloPricer(mkt, 40000, 0, 0.2)
+	mc.Process = lv.Process
+	mc.Steps = 50
+	mc.Seed = 5
+	fd := NewFiniteDifferencePricer(mkt, 200, 300, 0, 0.2)
+	fd.LocalVol = lv
+	for _, K := range []float64{80, 100, 120} {
+		// Out-of-the-money options carry the smile
+		optType := instrument.Call
+		if K < 100 {
+			optType = instrument.Put
+		}
+		opt := instrument.NewEuropeanOption("O", underlying, decimal.NewFromFloat(K), yearsFrom(now, 1), optType)
+		want, err := bs.Price(context.Background(), opt)
+		assert.NoError(t, err)
+
+		got, err := mc.Price(context.Background(), opt)
+		assert.NoError(t, err)
+		assert.InDelta(t, want.Value(), got.Value(), 3*got.StdErr+0.03, "monte carlo at %g", K)
+
+		pde, err := fd.Price(context.Background(), opt)
+		assert.NoError(t, err)
+		assert.InDelta(t, want.Value(), pde.Value(), 0.01, "finite difference at %g", K)
+	}
+
+	// Path-dependent options run on the same dynamics
+	barrier := instrument.NewBarrierOption("B", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call, instrument.UpAndOut, decimal.NewFromInt(130), decimal.Zero)
+	res, err := mc.Price(context.Background(), barrier)
+	assert.NoError(t, err)
+	vanilla, err := bs.Price(context.Background(), instrument.NewEuropeanOption("C", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call))
+	assert.NoError(t, err)
+	assert.Greater(t, res.Value(), 0.0)
+	assert.Less(t, res.Value(), vanilla.Value())
+}
+
+func TestFiniteDifferencePricer_LocalVolGreeks(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	surface := skewedSSVI(100, 0.04, 0)
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100).SetRiskFreeRate(0.04)
+	lv, err := NewLocalVolatility(surface, 100, 0.04, 0, 1)
+	assert.NoError(t, err)
+	fd := NewFiniteDifferencePricer(mkt, 100, 200, 0, 0.2)
+	fd.LocalVol = lv
+
+	// The local volatility ignores the flat one, so Vega is exactly zero
+	opt := instrument.NewEuropeanOption("C", instrument.NewEquity("TEST", "USD", "TEST"), decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+	g, err := fd.Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, g.Vega)
+	assert.Greater(t, g.Delta, 0.0)
+	assert.Greater(t, g.Rho, 0.0)
+}
@@ -3,108 +3,342 @@ package pricing
 import (
 	"context"
 	"math"
-	"math/rand"
-	"sync"
 	"time"
 
 	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+	"github.com/antigravity/go-finance-sdk/pkg/process"
 )
 
 // MonteCarloPricer implements Monte Carlo simulation for pricing.
+// RiskFreeRate and Volatility are defaults used when the market snapshot
+// does not quote them.
 type MonteCarloPricer struct {
+	Market       *market.Snapshot
 	Simulations  int
 	RiskFreeRate float64
 	Volatility   float64
-	rngPool      sync.Pool
+	// GreeksMethod selects the estimator used by Greeks; pathwise by default.
+	GreeksMethod GreeksMethod
+	// Steps is the number of exercise opportunities simulated for American
+	// options, of barrier and lookback monitoring dates and of time steps
+	// of Process paths; DefaultLSMSteps when zero.
+	Steps int
+	// Basis holds the Longstaff-Schwartz regressors for early-exercise
+	// options; LaguerreBasis(3) when nil.
+	Basis []BasisFunc
+	// Seed makes runs reproducible: the same non-zero seed always yields
+	// the same price bit-for-bit. Zero means unseeded, not a seed of its
+	// own: each call then draws a fresh seed from the clock, so its runs
+	// cannot be repeated.
+	Seed int64
+	// VarianceReduction selects the techniques applied to European prices
+	// and Greeks; techniques can be combined with |.
+	VarianceReduction VarianceReduction
+	// Source generates the normal draws; PseudoRandom when nil. Sobol and
+	// Halton give quasi-Monte Carlo, whose reported StdErr is the
+	// conservative independent-sampling bound.
+	Source RandomSource
+	// BrownianBridge builds multi-step GBM paths by Brownian-bridge
+	// bisection instead of forward increments, which pairs well with Sobol
+	// points. It does not apply to a custom Process.
+	BrownianBridge bool
+	// Process, when set, replaces the closed-form GBM terminal spot by
+	// Steps-step paths of a stochastic process such as Heston or Merton.
+	// Control variates and importance sampling assume GBM and are skipped,
+	// and Greeks are always computed by bump-and-revalue.
+	Process ProcessFunc
+	// Scheme discretises Process; its exact scheme when supported and
+	// Euler otherwise when empty.
+	Scheme process.Scheme
+	// Workers bounds the goroutines simulating a call; GOMAXPROCS when
+	// zero. Ignored when Pool is set.
+	Workers int
+	// BatchSize is the number of paths per unit of work; DefaultBatchSize
+	// when zero. Each batch draws its own random stream, so results depend
+	// on the seed and batch size but not on the number of workers.
+	BatchSize int
+	// Pool, when set, runs the batches on a long-lived WorkerPool shared
+	// with other pricers instead of goroutines started for each call.
+	Pool *WorkerPool
+	// Progress, when set, is called after every merged batch of a European
+	// price with the running estimate. Calls come from simulation
+	// goroutines but never overlap; see ProgressChannel.
+	Progress func(Progress)
+	// Tolerance stops a European price once the half-width of its
+	// confidence interval falls below it; zero simulates every path.
+	Tolerance float64
+	// Confidence is the level of the Tolerance interval; DefaultConfidence
+	// when zero.
+	Confidence float64
+	// TimeBudget stops a European price after the given wall-clock time
+	// and returns the estimate of the batches completed by then; zero
+	// means no limit.
+	TimeBudget time.Duration
 }
 
-// NewMonteCarloPricer creates a new Monte Carlo pricer.
-func NewMonteCarloPricer(sims int, r, sigma float64) *MonteCarloPricer {
+// NewMonteCarloPricer creates a new Monte Carlo pricer valuing against mkt.
+func NewMonteCarloPricer(mkt *market.Snapshot, sims int, r, sigma float64) *MonteCarloPricer {
 	return &MonteCarloPricer{
+		Market:       mkt,
 		Simulations:  sims,
 		RiskFreeRate: r,
 		Volatility:   sigma,
-		rngPool: sync.Pool{
-			New: func() interface{} {
-				return rand.New(rand.NewSource(time.Now().UnixNano()))
-			},
-		},
 	}
 }
 
-// Price calculates the price using concurrent simulations.
-func (mc *MonteCarloPricer) Price(ctx context.Context, inst instrument.Instrument) (float64, error) {
-	opt, ok := inst.(*instrument.Option)
-	if !ok {
-		return 0, nil
+// payoffStats accumulates the first two moments of simulated payoffs and
+// their cross moment with the primary estimator of the same path.
+type payoffStats struct {
+	sum      float64
+	sumSq    float64
+	sumCross float64
+}
+
+func (s *payoffStats) add(payoff float64) {
+	s.sum += payoff
+	s.sumSq += payoff * payoff
+}
+
+// addWith records payoff together with the primary estimator of its path.
+func (s *payoffStats) addWith(payoff, primary float64) {
+	s.add(payoff)
+	s.sumCross += payoff * primary
+}
+
+func (s *payoffStats) merge(other payoffStats) {
+	s.sum += other.sum
+	s.sumSq += other.sumSq
+	s.sumCross += other.sumCross
+}
+
+// meanStdErr returns the sample mean over n draws and its standard error.
+func (s payoffStats) meanStdErr(n int) (float64, float64) {
+	if n == 0 {
+		return 0, 0
+	}
+	mean := s.sum / float64(n)
+	if n < 2 {
+		return mean, 0
+	}
+	variance := (s.sumSq - float64(n)*mean*mean) / float64(n-1)
+	if variance < 0 {
+		variance = 0
+	}
+	return mean, math.Sqrt(variance / float64(n))
+}
+
+// sampler maps the standard normal draws z of one path to one value per
+// estimator in out. Keeping samplers free of randomness lets the engine
+// apply variance reduction uniformly.
+type sampler func(z []float64, out []float64)
+
+// simSpec describes one simulation run.
+type simSpec struct {
+	dim    int       // standard normals per path
+	width  int       // estimators per path
+	shift  []float64 // importance-sampling mean of the normals; nil for none
+	sample sampler
+	// estimate turns the running moments into a price and its standard
+	// error. When set, the run reports Progress and may stop early.
+	estimate func(stats []payoffStats, n int) (float64, float64)
+}
+
+// seed returns the seed for one pricing call, taken from the clock when
+// mc.Seed is zero.
+func (mc *MonteCarloPricer) seed() int64 {
+	if mc.Seed != 0 {
+		return mc.Seed
 	}
+	return time.Now().UnixNano()
+}
 
-	S0 := 100.0 // Placeholder spot
-	K, _ := opt.Strike().Float64()
-	T := time.Until(opt.Expiry()).Hours() / (24 * 365)
-	r := mc.RiskFreeRate
-	sigma := mc.Volatility
+// source returns the configured random source.
+func (mc *MonteCarloPricer) source() RandomSource {
+	if mc.Source == nil {
+		return PseudoRandom{}
+	}
+	return mc.Source
+}
 
-	// Parallel processing
-	numGoroutines := 10
-	simsPerRoutine := mc.Simulations / numGoroutines
-	results := make(chan float64, numGoroutines)
-	var wg sync.WaitGroup
+// streamSeed derives the seed of random stream i from the run seed with the
+// SplitMix64 finaliser, so that neighbouring streams are decorrelated.
+func streamSeed(seed int64, i int) int64 {
+	s := splitMix64(uint64(seed) + uint64(i)*0x9e3779b97f4a7c15)
+	return int64(s.next())
+}
+
+// simulate runs the paths in batches and returns the moments of each of
+// the estimators produced by spec.sample, with the number of paths behind
+// them. Batch b always draws stream b of seed, covering a fixed block of
+// path numbers, and batch results are merged in batch order, so equal seeds
+// give identical results and common random numbers across calls.
+func (mc *MonteCarloPricer) simulate(ctx context.Context, seed int64, spec simSpec) ([]payoffStats, int, error) {
+	plan, err := mc.plan()
+	if err != nil {
+		return nil, 0, err
+	}
 
-	for i := 0; i < numGoroutines; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	monitor := newRunMonitor(plan, spec.width)
+	if spec.estimate != nil {
+		monitor.estimate = spec.estimate
+		monitor.progress = mc.Progress
+		monitor.halfMax = mc.Tolerance
+		monitor.z = normInv(0.5 + 0.5*mc.confidence())
+		monitor.stop = stop
+		if mc.TimeBudget > 0 {
+			runCtx, stop = context.WithTimeout(runCtx, mc.TimeBudget)
+			defer stop()
+		}
+	}
 
-			// Check context before starting
-			if ctx.Err() != nil {
+	err = mc.runBatches(runCtx, plan.count(), func(batch int) {
+		first, n := plan.bounds(batch)
+		stream := mc.source().NewStream(seed, batch, first, spec.dim)
+		draw := mc.normals(stream, spec.dim, n)
+		path := newPathEvaluator(spec, mc.VarianceReduction.Has(Antithetic))
+		stats := make([]payoffStats, spec.width)
+		for j := 0; j < n; j++ {
+			// Check context periodically to avoid overhead
+			if j%1000 == 0 && runCtx.Err() != nil {
 				return
 			}
 
-			// Get RNG from pool
-			rng := mc.rngPool.Get().(*rand.Rand)
-			defer mc.rngPool.Put(rng)
-
-			sumPayoff := 0.0
-			for j := 0; j < simsPerRoutine; j++ {
-				// Check context periodically (every 1000 sims or so) to avoid overhead
-				if j%1000 == 0 && ctx.Err() != nil {
-					return
-				}
-
-				z := rng.NormFloat64()
-				ST := S0 * math.Exp((r-0.5*sigma*sigma)*T+sigma*math.Sqrt(T)*z)
-				payoff := 0.0
-				if opt.OptionType() == instrument.Call {
-					if ST > K {
-						payoff = ST - K
-					}
-				} else {
-					if K > ST {
-						payoff = K - ST
-					}
-				}
-				sumPayoff += payoff
+			out := path.eval(draw(j))
+			for k, v := range out {
+				stats[k].addWith(v, out[0])
 			}
-			results <- sumPayoff
-		}()
+		}
+		monitor.complete(batch, stats)
+	})
+	if ctx.Err() != nil {
+		return nil, 0, ctx.Err()
 	}
 
-	wg.Wait()
-	close(results)
+	// An early stop cancels runCtx; only fail if no batch made it
+	stats, paths := monitor.result()
+	if paths == 0 {
+		return nil, 0, err
+	}
+	return stats, paths, nil
+}
 
-	// fast exit if cancelled
-	if ctx.Err() != nil {
-		return 0, ctx.Err()
+// confidence returns the level of the Tolerance interval.
+func (mc *MonteCarloPricer) confidence() float64 {
+	if mc.Confidence == 0 {
+		return DefaultConfidence
 	}
+	return mc.Confidence
+}
 
-	totalPayoff := 0.0
-	for p := range results {
-		totalPayoff += p
+// terminalSpot returns the GBM terminal value for the standard normal draw z.
+func terminalSpot(in Inputs, z float64) float64 {
+	drift := (in.RiskFreeRate - in.DividendYield - 0.5*in.Volatility*in.Volatility) * in.Expiry
+	return in.Spot * math.Exp(drift+in.Volatility*math.Sqrt(in.Expiry)*z)
+}
+
+// vanillaPayoff returns the payoff of a call or put struck at K.
+func vanillaPayoff(optType instrument.OptionType, ST, K float64) float64 {
+	if optType == instrument.Call {
+		return math.Max(ST-K, 0)
 	}
+	return math.Max(K-ST, 0)
+}
 
-	averagePayoff := totalPayoff / float64(mc.Simulations)
-	discountedPrice := averagePayoff * math.Exp(-r*T)
+// Price calculates the price using concurrent simulations. American and
+// Bermudan options are valued with Longstaff-Schwartz regression, and
+// barrier, Asian, lookback, digital and structured options on simulated
+// paths. Basket, best-of, worst-of and spread options simulate their
+// underlyings jointly with the correlations quoted in the snapshot.
+func (mc *MonteCarloPricer) Price(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+	switch e := inst.(type) {
+	case *instrument.StructuredOption:
+		return mc.priceStructured(ctx, e)
+	case *instrument.MultiAssetOption:
+		return mc.priceMultiAsset(ctx, e)
+	}
+	if exoticTerms(inst) != nil {
+		return mc.priceExotic(ctx, inst)
+	}
+	opt, err := asOption(inst)
+	if err != nil {
+		return PricingResult{}, err
+	}
+
+	in, err := resolveInputs(mc.Market, opt, mc.RiskFreeRate, mc.Volatility)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	if opt.Style() != instrument.European {
+		return mc.priceEarlyExercise(ctx, in, opt)
+	}
 
-	return discountedPrice, nil
+	value, stdErr, paths, err := mc.value(ctx, in, opt.OptionType(), mc.seed(), true)
+	if err != nil {
+		return PricingResult{}, err
+	}
+
+	return PricingResult{
+		Price:  money.NewFromFloat(value, opt.Currency()),
+		Model:  ModelMonteCarlo,
+		Inputs: in,
+		StdErr: stdErr,
+		Diagnostics: map[string]float64{
+			"paths": float64(paths),
+		},
+	}, nil
+}
+
+// value returns the discounted mean payoff of a European option, its
+// standard error and the number of paths simulated, drawing from the
+// streams identified by seed. A monitored run reports Progress and honours
+// Tolerance and TimeBudget; bumped revaluations are not monitored so that
+// they keep common random numbers.
+func (mc *MonteCarloPricer) value(ctx context.Context, in Inputs, optType instrument.OptionType, seed int64, monitored bool) (float64, float64, int, error) {
+	if mc.Process == nil {
+		// All dividends have gone ex by expiry, where the escrowed spot
+		// meets the spot
+		in = in.escrowed()
+	}
+	df := math.Exp(-in.RiskFreeRate * in.Expiry)
+	forward := in.Spot * math.Exp((in.RiskFreeRate-in.DividendYield)*in.Expiry)
+	estimate := func(stats []payoffStats, n int) (float64, float64) {
+		var averagePayoff, stdErr float64
+		if mc.VarianceReduction.Has(ControlVariate) && mc.Process == nil {
+			averagePayoff, stdErr = controlVariateMean(stats[0], stats[1], forward, n)
+		} else {
+			averagePayoff, stdErr = stats[0].meanStdErr(n)
+		}
+		return averagePayoff * df, stdErr * df
+	}
+
+	spec := simSpec{
+		dim:   1,
+		width: 2,
+		shift: mc.importanceShift(in, optType),
+		sample: func(z []float64, out []float64) {
+			ST := terminalSpot(in, z[0])
+			out[0] = vanillaPayoff(optType, ST, in.Strike)
+			// The terminal spot, whose mean is the forward, is the control
+			out[1] = ST
+		},
+	}
+	if mc.Process != nil {
+		var err error
+		if spec, err = mc.processSpec(in, optType); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	if monitored {
+		spec.estimate = estimate
+	}
+	stats, paths, err := mc.simulate(ctx, seed, spec)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	value, stdErr := estimate(stats, paths)
+	return value, stdErr, paths, nil
 }
@@ -0,0 +1,196 @@
+package pricing
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+)
+
+// continuityCorrection is the Broadie, Glasserman and Kou (1997) constant
+// -zeta(1/2)/sqrt(2*pi). A barrier monitored every dt behaves like a
+// continuous one moved away from the spot by exp(continuityCorrection*
+// sigma*sqrt(dt)), so moving the monitored barrier towards the spot by
+// that factor approximates the continuously monitored closed forms.
+const continuityCorrection = 0.5826
+
+// priceExotic values a barrier, Asian, lookback or digital option on
+// simulated paths.
+func (mc *MonteCarloPricer) priceExotic(ctx context.Context, inst instrument.Instrument) (PricingResult, error) {
+	in, err := resolveExotic(mc.Market, inst, mc.RiskFreeRate, mc.Volatility)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	payoff, err := mc.exoticPayoff(in, inst, valuationTime(mc.Market))
+	if err != nil {
+		return PricingResult{}, err
+	}
+	value, stdErr, paths, err := mc.pathValue(ctx, in, payoff, mc.seed(), true)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	return PricingResult{
+		Price:  money.NewFromFloat(value, inst.Currency()),
+		Model:  ModelMonteCarlo,
+		Inputs: in,
+		StdErr: stdErr,
+		Diagnostics: map[string]float64{
+			"paths": float64(paths),
+		},
+	}, nil
+}
+
+// exoticGreeks revalues an exotic option under bumped inputs with common
+// random numbers.
+func (mc *MonteCarloPricer) exoticGreeks(ctx context.Context, inst instrument.Instrument) (Greeks, error) {
+	in, err := resolveExotic(mc.Market, inst, mc.RiskFreeRate, mc.Volatility)
+	if err != nil {
+		return Greeks{}, err
+	}
+	return mc.pathGreeks(ctx, in, valuationTime(mc.Market), func(x Inputs, asOf time.Time) (pathPayoff, error) {
+		return mc.exoticPayoff(x, inst, asOf)
+	})
+}
+
+// exoticPayoff builds the discounted path payoff of inst. Barriers and
+// lookbacks are monitored on the Steps grid with the continuity correction
+// applied for the spot's volatility; Asians are observed at their fixings.
+// The discounted vanilla payoff serves as control variate, except for
+// arithmetic Asians, which use their geometric counterpart when the
+// underlying pays no discrete dividends.
+func (mc *MonteCarloPricer) exoticPayoff(in Inputs, inst instrument.Instrument, asOf time.Time) (pathPayoff, error) {
+	S0, K, T, r := in.Spot, in.Strike, in.Expiry, in.RiskFreeRate
+	df := math.Exp(-r * T)
+	optType := exoticTerms(inst).OptionType()
+	last := func(path []float64) float64 { return path[len(path)-1] }
+
+	payoff := pathPayoff{
+		times: []float64{T},
+		control: func(path []float64) float64 {
+			return df * vanillaPayoff(optType, last(path), K)
+		},
+		controlMean: bsPrice(in, optType),
+	}
+	monitoring := func() (float64, error) {
+		grid, err := mc.timeGrid(T)
+		if err != nil {
+			return 0, err
+		}
+		payoff.times = grid
+		return continuityCorrection * in.Volatility * math.Sqrt(grid[0]), nil
+	}
+
+	switch e := inst.(type) {
+	case *instrument.BarrierOption:
+		shift, err := monitoring()
+		if err != nil {
+			return pathPayoff{}, err
+		}
+		bt := e.BarrierType()
+		H, rebate := e.Barrier().InexactFloat64(), e.Rebate().InexactFloat64()
+		crossed := func(s, h float64) bool {
+			if bt.Up() {
+				return s >= h
+			}
+			return s <= h
+		}
+		monitored := H * math.Exp(-shift)
+		if !bt.Up() {
+			monitored = H * math.Exp(shift)
+		}
+		times := payoff.times
+		payoff.value = func(path []float64) float64 {
+			hit, at := crossed(S0, H), 0.0
+			for k := 0; !hit && k < len(path); k++ {
+				if crossed(path[k], monitored) {
+					hit, at = true, times[k]
+				}
+			}
+			switch {
+			case bt.In() && hit:
+				return df * vanillaPayoff(optType, last(path), K)
+			case bt.In():
+				return df * rebate
+			case hit:
+				// Out rebates are paid when the barrier is hit
+				return math.Exp(-r*at) * rebate
+			default:
+				return df * vanillaPayoff(optType, last(path), K)
+			}
+		}
+
+	case *instrument.AsianOption:
+		times, err := fixingTimes(asOf, e)
+		if err != nil {
+			return pathPayoff{}, err
+		}
+		payoff.times = times
+		geometric := func(path []float64) float64 {
+			logSum := 0.0
+			for _, s := range path {
+				logSum += math.Log(s)
+			}
+			return df * vanillaPayoff(optType, math.Exp(logSum/float64(len(path))), K)
+		}
+		if e.Averaging() == instrument.GeometricAverage {
+			payoff.value = geometric
+			break
+		}
+		payoff.value = func(path []float64) float64 {
+			sum := 0.0
+			for _, s := range path {
+				sum += s
+			}
+			return df * vanillaPayoff(optType, sum/float64(len(path)), K)
+		}
+		if len(in.Dividends) == 0 {
+			payoff.control = geometric
+			payoff.controlMean = geometricAsianPrice(in, optType, times)
+		}
+
+	case *instrument.LookbackOption:
+		shift, err := monitoring()
+		if err != nil {
+			return pathPayoff{}, err
+		}
+		floating := e.StrikeType() == instrument.FloatingStrike
+		payoff.value = func(path []float64) float64 {
+			lo, hi := S0, S0
+			for _, s := range path {
+				lo, hi = math.Min(lo, s), math.Max(hi, s)
+			}
+			// Continuous extremes lie beyond the monitored ones
+			lo, hi = lo*math.Exp(-shift), hi*math.Exp(shift)
+			switch {
+			case floating && optType == instrument.Call:
+				return df * (last(path) - lo)
+			case floating:
+				return df * (hi - last(path))
+			case optType == instrument.Call:
+				return df * math.Max(hi-K, 0)
+			default:
+				return df * math.Max(K-lo, 0)
+			}
+		}
+
+	case *instrument.DigitalOption:
+		cash := e.Cash().InexactFloat64()
+		asset := e.Payout() == instrument.AssetOrNothing
+		payoff.value = func(path []float64) float64 {
+			ST := last(path)
+			if vanillaPayoff(optType, ST, K) <= 0 {
+				return 0
+			}
+			if asset {
+				return df * ST
+			}
+			return df * cash
+		}
+
+	default:
+		return pathPayoff{}, unsupported(inst)
+	}
+	return payoff, nil
+}
@@ -0,0 +1,157 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+)
+
+// GreeksMethod selects how MonteCarloPricer estimates sensitivities.
+type GreeksMethod string
+
+const (
+	// GreeksPathwise differentiates each simulated payoff with respect to
+	// its inputs. It reports delta, vega and rho; gamma uses the mixed
+	// pathwise/likelihood-ratio estimator since the vanilla payoff has a
+	// kink at the strike.
+	GreeksPathwise GreeksMethod = "pathwise"
+	// GreeksLikelihoodRatio weights each payoff by the score of the
+	// terminal spot density. It reports delta, gamma and vega and does not
+	// need a differentiable payoff.
+	GreeksLikelihoodRatio GreeksMethod = "likelihood-ratio"
+	// GreeksBumpAndRevalue revalues the option under bumped inputs with
+	// common random numbers and reports every field of Greeks.
+	GreeksBumpAndRevalue GreeksMethod = "bump-and-revalue"
+)
+
+var _ GreeksCalculator = (*MonteCarloPricer)(nil)
+
+// Greeks estimates the sensitivities of a European option using
+// mc.GreeksMethod. Those of exotic and structured options, and of options
+// on underlyings paying discrete dividends, are always computed by
+// bump-and-revalue.
+func (mc *MonteCarloPricer) Greeks(ctx context.Context, inst instrument.Instrument) (Greeks, error) {
+	if exoticTerms(inst) != nil {
+		return mc.exoticGreeks(ctx, inst)
+	}
+	if s, ok := inst.(*instrument.StructuredOption); ok {
+		return mc.structuredGreeks(ctx, s)
+	}
+	opt, err := asEuropeanOption(inst)
+	if err != nil {
+		return Greeks{}, err
+	}
+
+	in, err := resolveInputs(mc.Market, opt, mc.RiskFreeRate, mc.Volatility)
+	if err != nil {
+		return Greeks{}, err
+	}
+	seed := mc.seed()
+
+	method := mc.GreeksMethod
+	if mc.Process != nil || len(in.Dividends) > 0 {
+		// The pathwise and likelihood-ratio estimators are derived for GBM
+		// without discrete dividends
+		method = GreeksBumpAndRevalue
+	}
+	switch method {
+	case GreeksPathwise, "":
+		return mc.pathwiseGreeks(ctx, in, opt.OptionType(), seed)
+	case GreeksLikelihoodRatio:
+		return mc.likelihoodRatioGreeks(ctx, in, opt.OptionType(), seed)
+	case GreeksBumpAndRevalue:
+		return BumpGreeks(in, DefaultBumpSizes, func(x Inputs) (float64, error) {
+			if err := x.Validate(); err != nil {
+				return 0, err
+			}
+			v, _, _, err := mc.value(ctx, x, opt.OptionType(), seed, false)
+			return v, err
+		})
+	default:
+		return Greeks{}, fmt.Errorf("pricing: unknown Monte Carlo greeks method %q", mc.GreeksMethod)
+	}
+}
+
+// pathwiseGreeks differentiates the discounted payoff along each path.
+func (mc *MonteCarloPricer) pathwiseGreeks(ctx context.Context, in Inputs, optType instrument.OptionType, seed int64) (Greeks, error) {
+	S0, K, T := in.Spot, in.Strike, in.Expiry
+	sigma := in.Volatility
+	sqrtT := math.Sqrt(T)
+
+	stats, paths, err := mc.simulate(ctx, seed, simSpec{
+		dim:   1,
+		width: 5,
+		shift: mc.importanceShift(in, optType),
+		sample: func(zs []float64, out []float64) {
+			z := zs[0]
+			ST := terminalSpot(in, z)
+
+			// dPayoff/dST
+			slope := 0.0
+			if optType == instrument.Call && ST > K {
+				slope = 1
+			} else if optType == instrument.Put && ST < K {
+				slope = -1
+			}
+
+			out[0] = vanillaPayoff(optType, ST, K)
+			out[1] = slope * ST / S0
+			out[2] = slope * ST / (S0 * S0) * (z/(sigma*sqrtT) - 1)
+			out[3] = slope * ST * (sqrtT*z - sigma*T)
+			out[4] = slope * ST * T
+		},
+	})
+	if err != nil {
+		return Greeks{}, err
+	}
+
+	df := math.Exp(-in.RiskFreeRate * T)
+	mean := func(k int) float64 {
+		m, _ := stats[k].meanStdErr(paths)
+		return df * m
+	}
+	return Greeks{
+		Delta: mean(1),
+		Gamma: mean(2),
+		Vega:  mean(3),
+		Rho:   mean(4) - T*mean(0),
+	}, nil
+}
+
+// likelihoodRatioGreeks weights the discounted payoff by the derivatives of
+// the log-density of the terminal spot.
+func (mc *MonteCarloPricer) likelihoodRatioGreeks(ctx context.Context, in Inputs, optType instrument.OptionType, seed int64) (Greeks, error) {
+	S0, T := in.Spot, in.Expiry
+	sigma := in.Volatility
+	sqrtT := math.Sqrt(T)
+
+	stats, paths, err := mc.simulate(ctx, seed, simSpec{
+		dim:   1,
+		width: 3,
+		shift: mc.importanceShift(in, optType),
+		sample: func(zs []float64, out []float64) {
+			z := zs[0]
+			payoff := vanillaPayoff(optType, terminalSpot(in, z), in.Strike)
+
+			out[0] = payoff * z / (S0 * sigma * sqrtT)
+			out[1] = payoff * (z*z - 1 - z*sigma*sqrtT) / (S0 * S0 * sigma * sigma * T)
+			out[2] = payoff * ((z*z-1)/sigma - z*sqrtT)
+		},
+	})
+	if err != nil {
+		return Greeks{}, err
+	}
+
+	df := math.Exp(-in.RiskFreeRate * T)
+	mean := func(k int) float64 {
+		m, _ := stats[k].meanStdErr(paths)
+		return df * m
+	}
+	return Greeks{
+		Delta: mean(0),
+		Gamma: mean(1),
+		Vega:  mean(2),
+	}, nil
+}
@@ -0,0 +1,80 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonteCarloGreeks_MatchBlackScholes(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+
+	want, err := NewBlackScholesPricer(mkt, 0.05, 0.2).Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		method   GreeksMethod
+		withRho  bool
+		gammaTol float64
+		vegaTol  float64
+	}{
+		{method: GreeksPathwise, withRho: true, gammaTol: 0.001, vegaTol: 0.5},
+		// Score-weighted estimators have much higher variance
+		{method: GreeksLikelihoodRatio, gammaTol: 0.003, vegaTol: 3.0},
+		{method: GreeksBumpAndRevalue, withRho: true, gammaTol: 0.002, vegaTol: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			mc := NewMonteCarloPricer(mkt, 400000, 0.05, 0.2)
+			mc.GreeksMethod = tt.method
+			mc.Seed = 2024
+
+			got, err := mc.Greeks(context.Background(), opt)
+			assert.NoError(t, err)
+			assert.InDelta(t, want.Delta, got.Delta, 0.01)
+			assert.InDelta(t, want.Gamma, got.Gamma, tt.gammaTol)
+			assert.InDelta(t, want.Vega, got.Vega, tt.vegaTol)
+			if tt.withRho {
+				assert.InDelta(t, want.Rho, got.Rho, 1.0)
+			}
+		})
+	}
+}
+
+func TestMonteCarloGreeks_UnknownMethod(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Put)
+
+	mc := NewMonteCarloPricer(mkt, 1000, 0.05, 0.2)
+	mc.GreeksMethod = "adjoint"
+	_, err := mc.Greeks(context.Background(), opt)
+	assert.Error(t, err)
+}
+
+func TestBumpGreeks_MatchAnalytic(t *testing.T) {
+	in := Inputs{Spot: 95, Strike: 100, Expiry: 0.5, RiskFreeRate: 0.04, DividendYield: 0.01, Volatility: 0.3}
+	want := bsGreeks(in, instrument.Put)
+
+	got, err := BumpGreeks(in, DefaultBumpSizes, func(x Inputs) (float64, error) {
+		return bsPrice(x, instrument.Put), nil
+	})
+	assert.NoError(t, err)
+	assert.InDelta(t, want.Delta, got.Delta, 1e-3)
+	assert.InDelta(t, want.Gamma, got.Gamma, 1e-3)
+	assert.InDelta(t, want.Vega, got.Vega, 1e-2)
+	assert.InDelta(t, want.Rho, got.Rho, 1e-2)
+	assert.InDelta(t, want.Theta, got.Theta, 0.05)
+	assert.InDelta(t, want.Vanna, got.Vanna, 1e-2)
+	assert.InDelta(t, want.Volga, got.Volga, 0.5)
+	assert.InDelta(t, want.Charm, got.Charm, 0.01)
+}
@@ -0,0 +1,208 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+)
+
+// DefaultLSMSteps is the number of exercise opportunities simulated for an
+// American option when MonteCarloPricer.Steps is zero.
+const DefaultLSMSteps = 50
+
+// BasisFunc is one regressor of the Longstaff-Schwartz continuation value.
+// It is evaluated on the spot divided by the strike.
+type BasisFunc func(x float64) float64
+
+// PolynomialBasis returns the monomials 1, x, ..., x^degree.
+func PolynomialBasis(degree int) []BasisFunc {
+	basis := make([]BasisFunc, degree+1)
+	for n := range basis {
+		n := n
+		basis[n] = func(x float64) float64 { return math.Pow(x, float64(n)) }
+	}
+	return basis
+}
+
+// LaguerreBasis returns a constant followed by the first degree weighted
+// Laguerre polynomials exp(-x/2) L_n(x), as in Longstaff and Schwartz (2001).
+func LaguerreBasis(degree int) []BasisFunc {
+	basis := []BasisFunc{func(float64) float64 { return 1 }}
+	for n := 0; n < degree; n++ {
+		n := n
+		basis = append(basis, func(x float64) float64 {
+			// Three-term recurrence for L_n
+			l0, l1 := 1.0, 1-x
+			if n == 0 {
+				return math.Exp(-x/2) * l0
+			}
+			for k := 1; k < n; k++ {
+				l0, l1 = l1, ((2*float64(k)+1-x)*l1-float64(k)*l0)/float64(k+1)
+			}
+			return math.Exp(-x/2) * l1
+		})
+	}
+	return basis
+}
+
+// priceEarlyExercise values an American or Bermudan option by simulating
+// paths to each exercise time and choosing, backwards in time, to exercise
+// whenever the payoff beats the regressed continuation value.
+func (mc *MonteCarloPricer) priceEarlyExercise(ctx context.Context, in Inputs, opt *instrument.Option) (PricingResult, error) {
+	times, err := mc.exerciseTimes(in, opt)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	basis := mc.Basis
+	if basis == nil {
+		basis = LaguerreBasis(3)
+	}
+
+	paths, err := mc.simulatePaths(ctx, in, times, mc.seed())
+	if err != nil {
+		return PricingResult{}, err
+	}
+
+	value, stdErr, err := longstaffSchwartz(ctx, paths, times, in, opt.OptionType(), basis)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	if opt.Style() == instrument.American {
+		// Exercising today is always an alternative
+		value = math.Max(value, vanillaPayoff(opt.OptionType(), in.Spot, in.Strike))
+	}
+
+	return PricingResult{
+		Price:  money.NewFromFloat(value, opt.Currency()),
+		Model:  ModelLongstaffSchwartz,
+		Inputs: in,
+		StdErr: stdErr,
+		Diagnostics: map[string]float64{
+			"paths":          float64(len(paths)),
+			"exercise dates": float64(len(times)),
+			"basis":          float64(len(basis)),
+		},
+	}, nil
+}
+
+// exerciseTimes returns the exercise opportunities in years from valuation,
+// the last of which is the expiry.
+func (mc *MonteCarloPricer) exerciseTimes(in Inputs, opt *instrument.Option) ([]float64, error) {
+	if opt.Style() == instrument.Bermudan {
+		asOf := valuationTime(mc.Market)
+		var times []float64
+		for _, d := range opt.ExerciseDates() {
+			if t := yearFraction(asOf, d); t > 0 {
+				times = append(times, t)
+			}
+		}
+		return times, nil
+	}
+
+	return mc.timeGrid(in.Expiry)
+}
+
+// simulatePaths draws GBM paths, or paths of mc.Process, observed at
+// times, one row per path. Batch b draws stream b of seed, as in simulate.
+func (mc *MonteCarloPricer) simulatePaths(ctx context.Context, in Inputs, times []float64, seed int64) ([][]float64, error) {
+	plan, err := mc.plan()
+	if err != nil {
+		return nil, err
+	}
+	dim, fill, err := mc.pathFunc(in, times)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([][]float64, plan.paths)
+
+	err = mc.runBatches(ctx, plan.count(), func(batch int) {
+		first, n := plan.bounds(batch)
+		stream := mc.source().NewStream(seed, batch, first, dim)
+		z := make([]float64, dim)
+		for j := 0; j < n; j++ {
+			if j%1000 == 0 && ctx.Err() != nil {
+				return
+			}
+
+			stream.Next(z)
+			path := make([]float64, len(times))
+			fill(z, path)
+			paths[first+j] = path
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// longstaffSchwartz runs the backward regression over simulated paths and
+// returns the discounted value of the resulting exercise policy with its
+// standard error.
+func longstaffSchwartz(ctx context.Context, paths [][]float64, times []float64, in Inputs, optType instrument.OptionType, basis []BasisFunc) (float64, float64, error) {
+	if len(paths) == 0 || len(times) == 0 {
+		return 0, 0, fmt.Errorf("%w: no paths to regress", ErrInvalidInput)
+	}
+	last := len(times) - 1
+	K, r := in.Strike, in.RiskFreeRate
+
+	// Cash flow of each path and the time index at which it is received
+	cash := make([]float64, len(paths))
+	when := make([]int, len(paths))
+	for i, path := range paths {
+		cash[i] = vanillaPayoff(optType, path[last], K)
+		when[i] = last
+	}
+
+	var X [][]float64
+	var y []float64
+	var itm []int
+	for k := last - 1; k >= 0; k-- {
+		if ctx.Err() != nil {
+			return 0, 0, ctx.Err()
+		}
+
+		// Regress discounted future cash flows of in-the-money paths
+		X, y, itm = X[:0], y[:0], itm[:0]
+		for i, path := range paths {
+			if vanillaPayoff(optType, path[k], K) <= 0 {
+				continue
+			}
+			row := make([]float64, len(basis))
+			for b, f := range basis {
+				row[b] = f(path[k] / K)
+			}
+			X = append(X, row)
+			y = append(y, cash[i]*math.Exp(-r*(times[when[i]]-times[k])))
+			itm = append(itm, i)
+		}
+		if len(itm) <= len(basis) {
+			continue
+		}
+		beta, err := leastSquares(X, y)
+		if err != nil {
+			continue
+		}
+
+		for n, i := range itm {
+			continuation := 0.0
+			for b, c := range beta {
+				continuation += c * X[n][b]
+			}
+			if exercise := vanillaPayoff(optType, paths[i][k], K); exercise > continuation {
+				cash[i] = exercise
+				when[i] = k
+			}
+		}
+	}
+
+	var stats payoffStats
+	for i := range paths {
+		stats.add(cash[i] * math.Exp(-r*times[when[i]]))
+	}
+	value, stdErr := stats.meanStdErr(len(paths))
+	return value, stdErr, nil
+}
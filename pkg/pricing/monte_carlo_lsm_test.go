@@ -0,0 +1,89 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonteCarloPricer_AmericanPutLongstaffSchwartz(t *testing.T) {
+	// Hull: S=50, K=50, r=10%, sigma=40%, T=5 months. American put ~4.28
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 50)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	opt := instrument.NewAmericanOption("AP", underlying, decimal.NewFromInt(50), yearsFrom(now, 5.0/12), instrument.Put)
+
+	for name, basis := range map[string][]BasisFunc{
+		"laguerre":   nil,
+		"polynomial": PolynomialBasis(3),
+	} {
+		t.Run(name, func(t *testing.T) {
+			mc := NewMonteCarloPricer(mkt, 100000, 0.10, 0.40)
+			mc.Basis = basis
+			mc.Seed = 2024
+
+			res, err := mc.Price(context.Background(), opt)
+			assert.NoError(t, err)
+			assert.Equal(t, ModelLongstaffSchwartz, res.Model)
+			assert.InDelta(t, 4.28, res.Value(), 0.06)
+			assert.Greater(t, res.StdErr, 0.0)
+			assert.Equal(t, float64(DefaultLSMSteps), res.Diagnostics["exercise dates"])
+		})
+	}
+}
+
+func TestMonteCarloPricer_Bermudan(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	expiry := yearsFrom(now, 1)
+	strike := decimal.NewFromInt(110)
+
+	european, err := NewBlackScholesPricer(mkt, 0.06, 0.25).Price(context.Background(),
+		instrument.NewEuropeanOption("EP", underlying, strike, expiry, instrument.Put))
+	assert.NoError(t, err)
+	american, err := NewLatticePricer(mkt, BinomialCRR, 1000, 0.06, 0.25).Price(context.Background(),
+		instrument.NewAmericanOption("AP", underlying, strike, expiry, instrument.Put))
+	assert.NoError(t, err)
+
+	mc := NewMonteCarloPricer(mkt, 100000, 0.06, 0.25)
+	mc.Seed = 2024
+
+	// A single exercise date is a European option
+	single := instrument.NewBermudanOption("BP1", underlying, strike, []time.Time{expiry}, instrument.Put)
+	res, err := mc.Price(context.Background(), single)
+	assert.NoError(t, err)
+	assert.InDelta(t, european.Value(), res.Value(), 4*res.StdErr)
+
+	// Quarterly exercise sits between the European and American values
+	quarterly := instrument.NewBermudanOption("BP4", underlying, strike, []time.Time{
+		yearsFrom(now, 0.25), yearsFrom(now, 0.5), yearsFrom(now, 0.75), expiry,
+	}, instrument.Put)
+	res, err = mc.Price(context.Background(), quarterly)
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, res.Diagnostics["exercise dates"])
+	assert.Greater(t, res.Value(), european.Value())
+	assert.Less(t, res.Value(), american.Value())
+
+	// Trees have no exercise schedule
+	_, err = NewLatticePricer(mkt, BinomialCRR, 100, 0.06, 0.25).Price(context.Background(), quarterly)
+	assert.ErrorIs(t, err, ErrUnsupportedInstrument)
+}
+
+func TestLeastSquares(t *testing.T) {
+	// y = 1 + 2x - 0.5x^2 exactly
+	var X [][]float64
+	var y []float64
+	for x := -2.0; x <= 2; x += 0.5 {
+		X = append(X, []float64{1, x, x * x})
+		y = append(y, 1+2*x-0.5*x*x)
+	}
+	beta, err := leastSquares(X, y)
+	assert.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{1, 2, -0.5}, beta, 1e-9)
+}
@@ -0,0 +1,193 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// multiAssetFixture returns a market of two correlated equities.
+func multiAssetFixture(now time.Time, rho float64) (*market.Snapshot, *instrument.Equity, *instrument.Equity) {
+	mkt := market.NewSnapshot(now).
+		SetSpot("AAPL", 100).SetVolatility("AAPL", 0.3).SetDividendYield("AAPL", 0.01).
+		SetSpot("MSFT", 90).SetVolatility("MSFT", 0.2).SetDividendYield("MSFT", 0.03).
+		SetCorrelation("AAPL", "MSFT", rho)
+	return mkt, instrument.NewEquity("AAPL", "USD", "AAPL"), instrument.NewEquity("MSFT", "USD", "MSFT")
+}
+
+func TestMonteCarloPricer_SpreadMatchesMargrabe(t *testing.T) {
+	now := time.Now()
+	mc := NewMonteCarloPricer(nil, 200000, 0.05, 0)
+	mc.Seed = 17
+	for _, rho := range []float64{-0.5, 0, 0.7} {
+		mkt, aapl, msft := multiAssetFixture(now, rho)
+		mc.Market = mkt
+		exchange := instrument.NewSpreadOption("X", aapl, msft, decimal.Zero, yearsFrom(now, 1), instrument.Call)
+
+		res, err := mc.Price(context.Background(), exchange)
+		assert.NoError(t, err)
+
+		// Margrabe (1978): the option to exchange MSFT for AAPL
+		sigma := math.Sqrt(0.3*0.3 + 0.2*0.2 - 2*rho*0.3*0.2)
+		d1 := (math.Log(100.0/90) + (0.03-0.01+0.5*sigma*sigma)*1) / sigma
+		want := 100*math.Exp(-0.01)*normCdf(d1) - 90*math.Exp(-0.03)*normCdf(d1-sigma)
+		assert.InDelta(t, want, res.Price.Amount().InexactFloat64(), 3*res.StdErr, "rho=%v", rho)
+		assert.Equal(t, 0.0, res.Diagnostics["correlation repair"])
+	}
+}
+
+func TestMonteCarloPricer_BasketOfPerfectlyCorrelatedIsVanilla(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).
+		SetSpot("A", 100).SetVolatility("A", 0.25).
+		SetSpot("B", 100).SetVolatility("B", 0.25).
+		SetCorrelation("A", "B", 1)
+	a := instrument.NewEquity("A", "USD", "A")
+	b := instrument.NewEquity("B", "USD", "B")
+	half := decimal.NewFromFloat(0.5)
+	basket := instrument.NewBasketOption("BASKET", []instrument.Instrument{a, b}, []decimal.Decimal{half, half}, decimal.NewFromInt(105), yearsFrom(now, 1), instrument.Put)
+
+	mc := NewMonteCarloPricer(mkt, 100000, 0.04, 0)
+	mc.Seed = 2
+	res, err := mc.Price(context.Background(), basket)
+	assert.NoError(t, err)
+
+	want := bsPrice(Inputs{Spot: 100, Strike: 105, Expiry: 1, RiskFreeRate: 0.04, Volatility: 0.25}, instrument.Put)
+	assert.InDelta(t, want, res.Price.Amount().InexactFloat64(), 3*res.StdErr)
+
+	// The basket is then its own geometric average: the control is exact
+	mc.VarianceReduction = ControlVariate
+	res, err = mc.Price(context.Background(), basket)
+	assert.NoError(t, err)
+	assert.InDelta(t, want, res.Price.Amount().InexactFloat64(), 1e-9)
+}
+
+func TestMonteCarloPricer_BasketWithDividends(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).
+		SetSpot("A", 100).SetVolatility("A", 0.25).
+		SetSpot("B", 100).SetVolatility("B", 0.25).
+		SetCorrelation("A", "B", 1)
+	// Both pay 3% before expiry, so the basket is a vanilla on 97
+	div := instrument.NewProportionalDividend(yearsFrom(now, 0.5), decimal.NewFromFloat(0.03))
+	a := instrument.NewEquity("A", "USD", "A").WithDividends(div)
+	b := instrument.NewEquity("B", "USD", "B").WithDividends(div)
+	half := decimal.NewFromFloat(0.5)
+	basket := instrument.NewBasketOption("BASKET", []instrument.Instrument{a, b}, []decimal.Decimal{half, half}, decimal.NewFromInt(95), yearsFrom(now, 1), instrument.Call)
+
+	mc := NewMonteCarloPricer(mkt, 10000, 0.04, 0)
+	mc.VarianceReduction = ControlVariate
+	res, err := mc.Price(context.Background(), basket)
+	assert.NoError(t, err)
+
+	want := bsPrice(Inputs{Spot: 97, Strike: 95, Expiry: 1, RiskFreeRate: 0.04, Volatility: 0.25}, instrument.Call)
+	assert.InDelta(t, want, res.Price.Amount().InexactFloat64(), 1e-9)
+}
+
+func TestMonteCarloPricer_BasketControlVariate(t *testing.T) {
+	now := time.Now()
+	mkt, aapl, msft := multiAssetFixture(now, 0.4)
+	weights := []decimal.Decimal{decimal.NewFromFloat(0.5), decimal.NewFromFloat(0.5)}
+	basket := instrument.NewBasketOption("BASKET", []instrument.Instrument{aapl, msft}, weights, decimal.NewFromInt(95), yearsFrom(now, 1), instrument.Call)
+
+	mc := NewMonteCarloPricer(mkt, 50000, 0.05, 0)
+	mc.Seed = 8
+	plain, err := mc.Price(context.Background(), basket)
+	assert.NoError(t, err)
+	mc.VarianceReduction = ControlVariate
+	controlled, err := mc.Price(context.Background(), basket)
+	assert.NoError(t, err)
+
+	assert.Less(t, controlled.StdErr, plain.StdErr/5)
+	assert.InDelta(t, plain.Price.Amount().InexactFloat64(), controlled.Price.Amount().InexactFloat64(), 3*plain.StdErr)
+}
+
+func TestMonteCarloPricer_BestAndWorstOf(t *testing.T) {
+	now := time.Now()
+	mkt, aapl, msft := multiAssetFixture(now, 0.3)
+	assets := []instrument.Instrument{aapl, msft}
+	expiry := yearsFrom(now, 1)
+
+	mc := NewMonteCarloPricer(mkt, 50000, 0.05, 0)
+	mc.Seed = 4
+	price := func(inst instrument.Instrument) (float64, float64) {
+		res, err := mc.Price(context.Background(), inst)
+		assert.NoError(t, err, inst.ID())
+		return res.Price.Amount().InexactFloat64(), res.StdErr
+	}
+
+	// max + min = sum on every path, so zero-strike calls add up to the forwards
+	best, _ := price(instrument.NewBestOfOption("BEST", assets, decimal.Zero, expiry, instrument.Call))
+	worst, _ := price(instrument.NewWorstOfOption("WORST", assets, decimal.Zero, expiry, instrument.Call))
+	forwards, stdErr := price(instrument.NewBasketOption("SUM", assets, []decimal.Decimal{decimal.NewFromInt(1), decimal.NewFromInt(1)}, decimal.Zero, expiry, instrument.Call))
+	assert.InDelta(t, forwards, best+worst, 1e-9)
+	assert.InDelta(t, 100*math.Exp(-0.01)+90*math.Exp(-0.03), forwards, 3*stdErr)
+
+	// A best-of call dominates calls on either asset, a worst-of call neither
+	K := decimal.NewFromInt(95)
+	bestCall, _ := price(instrument.NewBestOfOption("BEST", assets, K, expiry, instrument.Call))
+	worstCall, _ := price(instrument.NewWorstOfOption("WORST", assets, K, expiry, instrument.Call))
+	aaplCall := bsPrice(Inputs{Spot: 100, Strike: 95, Expiry: 1, RiskFreeRate: 0.05, DividendYield: 0.01, Volatility: 0.3}, instrument.Call)
+	msftCall := bsPrice(Inputs{Spot: 90, Strike: 95, Expiry: 1, RiskFreeRate: 0.05, DividendYield: 0.03, Volatility: 0.2}, instrument.Call)
+	assert.Greater(t, bestCall, math.Max(aaplCall, msftCall))
+	assert.Less(t, worstCall, math.Min(aaplCall, msftCall))
+}
+
+func TestMonteCarloPricer_MultiAssetCorrelationRepair(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).
+		SetSpot("A", 100).SetSpot("B", 100).SetSpot("C", 100).
+		SetCorrelation("A", "B", 0.9).SetCorrelation("A", "C", 0.7).SetCorrelation("B", "C", -0.4)
+	assets := []instrument.Instrument{
+		instrument.NewEquity("A", "USD", "A"),
+		instrument.NewEquity("B", "USD", "B"),
+		instrument.NewEquity("C", "USD", "C"),
+	}
+	third := decimal.NewFromFloat(1.0 / 3)
+	basket := instrument.NewBasketOption("BASKET", assets, []decimal.Decimal{third, third, third}, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+
+	mc := NewMonteCarloPricer(mkt, 10000, 0.05, 0.2)
+	mc.Seed = 1
+	res, err := mc.Price(context.Background(), basket)
+	assert.NoError(t, err)
+	assert.Greater(t, res.Diagnostics["correlation repair"], 0.0)
+	assert.Greater(t, res.Price.Amount().InexactFloat64(), 0.0)
+}
+
+func TestMonteCarloPricer_MultiAssetErrors(t *testing.T) {
+	now := time.Now()
+	mkt, aapl, msft := multiAssetFixture(now, 0.3)
+	expiry := yearsFrom(now, 1)
+	K := decimal.NewFromInt(100)
+	mc := NewMonteCarloPricer(mkt, 1000, 0.05, 0)
+
+	goog := instrument.NewEquity("GOOG", "USD", "GOOG")
+	mkt.SetSpot("GOOG", 150).SetVolatility("GOOG", 0.3)
+	_, err := mc.Price(context.Background(), instrument.NewBestOfOption("NOCORR", []instrument.Instrument{aapl, goog}, K, expiry, instrument.Call))
+	assert.True(t, errors.Is(err, ErrMissingMarketData))
+
+	vod := instrument.NewEquity("VOD", "GBP", "VOD")
+	_, err = mc.Price(context.Background(), instrument.NewSpreadOption("QUANTO", aapl, vod, K, expiry, instrument.Call))
+	assert.True(t, errors.Is(err, ErrUnsupportedInstrument))
+
+	spread := instrument.NewRainbowOption("WIDE", []instrument.Instrument{aapl, msft, goog}, []decimal.Decimal{K, K, K}, K, expiry, instrument.Call, instrument.SpreadPayout)
+	_, err = mc.Price(context.Background(), spread)
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+
+	_, err = mc.Price(context.Background(), instrument.NewBasketOption("W", []instrument.Instrument{aapl, msft}, []decimal.Decimal{K}, K, expiry, instrument.Call))
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+
+	_, err = mc.Price(context.Background(), instrument.NewBestOfOption("OLD", []instrument.Instrument{aapl, msft}, K, now.Add(-time.Hour), instrument.Call))
+	assert.True(t, errors.Is(err, ErrExpired))
+
+	mc.Process = GBMProcess
+	_, err = mc.Price(context.Background(), instrument.NewBestOfOption("PROC", []instrument.Instrument{aapl, msft}, K, expiry, instrument.Call))
+	assert.True(t, errors.Is(err, ErrUnsupportedInstrument))
+}
@@ -0,0 +1,175 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/money"
+	"github.com/antigravity/go-finance-sdk/pkg/payoff"
+)
+
+// pathPayoff is a path-dependent payoff observed at times.
+type pathPayoff struct {
+	times []float64
+	// value returns the discounted payoff of a path.
+	value func(path []float64) float64
+	// control returns a discounted control variate of the path whose
+	// expectation is controlMean; nil for none.
+	control     func(path []float64) float64
+	controlMean float64
+}
+
+// pathValue returns the mean discounted payoff over simulated paths, its
+// standard error and the number of paths, like value does for vanillas.
+func (mc *MonteCarloPricer) pathValue(ctx context.Context, in Inputs, payoff pathPayoff, seed int64, monitored bool) (float64, float64, int, error) {
+	dim, fill, err := mc.pathFunc(in, payoff.times)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	useControl := payoff.control != nil && mc.VarianceReduction.Has(ControlVariate) && mc.Process == nil
+	estimate := func(stats []payoffStats, n int) (float64, float64) {
+		if useControl {
+			return controlVariateMean(stats[0], stats[1], payoff.controlMean, n)
+		}
+		return stats[0].meanStdErr(n)
+	}
+	spec := simSpec{
+		dim:   dim,
+		width: 2,
+		sample: func(z []float64, out []float64) {
+			path := make([]float64, len(payoff.times))
+			fill(z, path)
+			out[0] = payoff.value(path)
+			out[1] = out[0]
+			if useControl {
+				out[1] = payoff.control(path)
+			}
+		},
+	}
+	if monitored {
+		spec.estimate = estimate
+	}
+	stats, paths, err := mc.simulate(ctx, seed, spec)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	value, stdErr := estimate(stats, paths)
+	if math.IsNaN(value) {
+		return 0, 0, 0, fmt.Errorf("%w: payoff is not a number on some paths", ErrInvalidInput)
+	}
+	return value, stdErr, paths, nil
+}
+
+// pathGreeks revalues a path-dependent payoff under bumped inputs with
+// common random numbers. build returns the payoff for bumped inputs and the
+// valuation time matching their expiry, so that fixed dates stay put while
+// time passes.
+func (mc *MonteCarloPricer) pathGreeks(ctx context.Context, in Inputs, asOf time.Time, build func(x Inputs, asOf time.Time) (pathPayoff, error)) (Greeks, error) {
+	seed := mc.seed()
+	return BumpGreeks(in, DefaultBumpSizes, func(x Inputs) (float64, error) {
+		if err := x.Validate(); err != nil {
+			return 0, err
+		}
+		shifted := asOf.Add(time.Duration((in.Expiry - x.Expiry) * 365 * 24 * float64(time.Hour)))
+		payoff, err := build(x, shifted)
+		if err != nil {
+			return 0, err
+		}
+		v, _, _, err := mc.pathValue(ctx, x, payoff, seed, false)
+		return v, err
+	})
+}
+
+// resolveStructured resolves the inputs of a structured option. It has no
+// strike of its own; the inputs report the spot instead.
+func resolveStructured(mkt *market.Snapshot, s *instrument.StructuredOption, r, sigma float64) (Inputs, error) {
+	if s.Payoff() == nil {
+		return Inputs{}, fmt.Errorf("%w: structured option %s has no payoff", ErrInvalidInput, s.ID())
+	}
+	in, err := marketInputs(mkt, s, 0, r, sigma)
+	if err != nil {
+		return Inputs{}, err
+	}
+	in.Strike = in.Spot
+	if err := in.Validate(); err != nil {
+		return Inputs{}, err
+	}
+	return in, nil
+}
+
+// priceStructured values a structured option by evaluating its payoff on
+// simulated paths.
+func (mc *MonteCarloPricer) priceStructured(ctx context.Context, s *instrument.StructuredOption) (PricingResult, error) {
+	in, err := resolveStructured(mc.Market, s, mc.RiskFreeRate, mc.Volatility)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	payoff, err := mc.structuredPayoff(in, s, valuationTime(mc.Market))
+	if err != nil {
+		return PricingResult{}, err
+	}
+	value, stdErr, paths, err := mc.pathValue(ctx, in, payoff, mc.seed(), true)
+	if err != nil {
+		return PricingResult{}, err
+	}
+	return PricingResult{
+		Price:  money.NewFromFloat(value, s.Currency()),
+		Model:  ModelMonteCarlo,
+		Inputs: in,
+		StdErr: stdErr,
+		Diagnostics: map[string]float64{
+			"paths": float64(paths),
+		},
+	}, nil
+}
+
+// structuredGreeks bumps and revalues a structured option.
+func (mc *MonteCarloPricer) structuredGreeks(ctx context.Context, s *instrument.StructuredOption) (Greeks, error) {
+	in, err := resolveStructured(mc.Market, s, mc.RiskFreeRate, mc.Volatility)
+	if err != nil {
+		return Greeks{}, err
+	}
+	return mc.pathGreeks(ctx, in, valuationTime(mc.Market), func(x Inputs, asOf time.Time) (pathPayoff, error) {
+		return mc.structuredPayoff(x, s, asOf)
+	})
+}
+
+// structuredPayoff observes the path on the option's observation dates,
+// or on the Steps grid when it has none, and always at expiry.
+func (mc *MonteCarloPricer) structuredPayoff(in Inputs, s *instrument.StructuredOption, asOf time.Time) (pathPayoff, error) {
+	var times []float64
+	for _, d := range s.ObservationDates() {
+		t := yearFraction(asOf, d)
+		switch {
+		case t <= 0:
+			return pathPayoff{}, fmt.Errorf("%w: observation of %s on %s has passed", ErrMissingMarketData, s.ID(), d.Format(time.DateOnly))
+		case t > in.Expiry:
+			return pathPayoff{}, fmt.Errorf("%w: observation of %s on %s is after expiry", ErrInvalidInput, s.ID(), d.Format(time.DateOnly))
+		}
+		times = append(times, t)
+	}
+	if len(times) == 0 {
+		grid, err := mc.timeGrid(in.Expiry)
+		if err != nil {
+			return pathPayoff{}, err
+		}
+		times = grid
+	}
+	if times[len(times)-1] < in.Expiry {
+		times = append(times, in.Expiry)
+	}
+
+	p := s.Payoff()
+	df := math.Exp(-in.RiskFreeRate * in.Expiry)
+	return pathPayoff{
+		times: times,
+		value: func(path []float64) float64 {
+			return df * p.Evaluate(payoff.Path{Initial: in.Spot, Times: times, Spots: path})
+		},
+	}, nil
+}
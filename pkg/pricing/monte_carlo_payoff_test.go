@@ -0,0 +1,101 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/payoff"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonteCarloPricer_StructuredMatchesBuiltIns(t *testing.T) {
+	now := time.Now()
+	mkt, underlying, fixings := exoticFixture(now)
+	expiry := yearsFrom(now, 1)
+	K := decimal.NewFromInt(100)
+	params := map[string]float64{"K": 100, "B": 120}
+
+	tests := []struct {
+		expr         string
+		observations []time.Time
+		want         instrument.Instrument
+		corrected    bool // the built-in applies the continuity correction
+	}{
+		{"max(S - K, 0)", []time.Time{expiry}, instrument.NewEuropeanOption("CALL", underlying, K, expiry, instrument.Call), false},
+		{"max(avg(S) - K, 0)", fixings, instrument.NewAsianOption("ASIAN", underlying, K, fixings, instrument.Call, instrument.ArithmeticAverage), false},
+		{"if(max(S) < B, max(S - K, 0), 0)", nil, instrument.NewBarrierOption("UOC", underlying, K, expiry, instrument.Call, instrument.UpAndOut, decimal.NewFromInt(120), decimal.Zero), true},
+	}
+
+	mc := NewMonteCarloPricer(mkt, 20000, 0.05, 0)
+	mc.Seed = 5
+	for _, tt := range tests {
+		p, err := payoff.Parse(tt.expr, params)
+		assert.NoError(t, err, tt.expr)
+		structured := instrument.NewStructuredOption("S", underlying, expiry, tt.observations, p)
+
+		got, err := mc.Price(context.Background(), structured)
+		assert.NoError(t, err, tt.expr)
+		want, err := mc.Price(context.Background(), tt.want)
+		assert.NoError(t, err, tt.expr)
+		if tt.corrected {
+			// Discrete monitoring knocks out less often
+			assert.Less(t, want.Price.Amount().InexactFloat64(), got.Price.Amount().InexactFloat64(), tt.expr)
+			continue
+		}
+		// Same seed, same paths
+		assert.InDelta(t, want.Price.Amount().InexactFloat64(), got.Price.Amount().InexactFloat64(), 1e-9, tt.expr)
+		assert.InDelta(t, want.StdErr, got.StdErr, 1e-12, tt.expr)
+	}
+}
+
+func TestMonteCarloPricer_StructuredGreeks(t *testing.T) {
+	now := time.Now()
+	mkt, underlying, _ := exoticFixture(now)
+	expiry := yearsFrom(now, 1)
+	call := instrument.NewStructuredOption("CALL", underlying, expiry, nil, payoff.Call(payoff.Spot(), 100))
+
+	mc := NewMonteCarloPricer(mkt, 50000, 0.05, 0)
+	mc.Seed = 9
+	g, err := mc.Greeks(context.Background(), call)
+	assert.NoError(t, err)
+
+	in := Inputs{Spot: 100, Strike: 100, Expiry: 1, RiskFreeRate: 0.05, DividendYield: 0.02, Volatility: 0.25}
+	want := bsGreeks(in, instrument.Call)
+	assert.InDelta(t, want.Delta, g.Delta, 0.02)
+	assert.InDelta(t, want.Vega, g.Vega, 0.05*want.Vega)
+	assert.InDelta(t, want.Rho, g.Rho, 0.05*want.Rho)
+}
+
+func TestMonteCarloPricer_StructuredErrors(t *testing.T) {
+	now := time.Now()
+	mkt, underlying, _ := exoticFixture(now)
+	expiry := yearsFrom(now, 1)
+	mc := NewMonteCarloPricer(mkt, 1000, 0.05, 0)
+	p := payoff.Call(payoff.Spot(), 100)
+
+	late := instrument.NewStructuredOption("LATE", underlying, expiry, []time.Time{yearsFrom(now, 2)}, p)
+	_, err := mc.Price(context.Background(), late)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	seasoned := instrument.NewStructuredOption("SEASONED", underlying, expiry, []time.Time{now.Add(-time.Hour)}, p)
+	_, err = mc.Price(context.Background(), seasoned)
+	assert.ErrorIs(t, err, ErrMissingMarketData)
+
+	// The path has fewer observations than the payoff indexes
+	short, err := payoff.Parse("S[5]", nil)
+	assert.NoError(t, err)
+	twoDates := []time.Time{yearsFrom(now, 0.5), expiry}
+	_, err = mc.Price(context.Background(), instrument.NewStructuredOption("SHORT", underlying, expiry, twoDates, short))
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = mc.Price(context.Background(), instrument.NewStructuredOption("NONE", underlying, expiry, nil, nil))
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	// Only the Monte Carlo pricer evaluates arbitrary payoffs
+	_, err = NewBlackScholesPricer(mkt, 0.05, 0).Price(context.Background(), late)
+	assert.True(t, errors.Is(err, ErrUnsupportedInstrument))
+}
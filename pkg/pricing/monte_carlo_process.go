@@ -0,0 +1,156 @@
+package pricing
+
+import (
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/process"
+)
+
+// ProcessFunc builds the stochastic process simulated for an option from
+// its resolved inputs. Element 0 of the process state is the spot.
+type ProcessFunc func(in Inputs) process.Process
+
+// GBMProcess is the process MonteCarloPricer simulates by default: a
+// geometric Brownian motion with drift r-q and volatility sigma.
+func GBMProcess(in Inputs) process.Process {
+	return process.GBM{S0: in.Spot, Drift: in.RiskFreeRate - in.DividendYield, Vol: in.Volatility}
+}
+
+// newProcess returns the process for inputs in and the scheme stepping it.
+func (mc *MonteCarloPricer) newProcess(in Inputs) (process.Process, process.Scheme, error) {
+	build := mc.Process
+	if build == nil {
+		build = GBMProcess
+	}
+	p := build(in)
+	scheme, err := process.Resolve(p, mc.Scheme)
+	if err != nil {
+		return nil, "", err
+	}
+	return p, scheme, nil
+}
+
+// timeGrid returns Steps equally spaced times ending at expiry.
+func (mc *MonteCarloPricer) timeGrid(expiry float64) ([]float64, error) {
+	steps := mc.Steps
+	if steps == 0 {
+		steps = DefaultLSMSteps
+	}
+	if steps < 1 {
+		return nil, &InputError{Field: "steps", Value: float64(steps)}
+	}
+	times := make([]float64, steps)
+	for i := range times {
+		times[i] = expiry * float64(i+1) / float64(steps)
+	}
+	return times, nil
+}
+
+// pathFunc returns the number of normals drawn per path and a function
+// filling path with the spot at each of the increasing times. GBM paths
+// are sampled exactly, by forward increments or by Brownian bridge;
+// mc.Process paths are stepped on times merged with the Steps grid so that
+// sparse observations do not coarsen the scheme. Under discrete dividends
+// the escrowed spot is simulated and the dividends still to go ex are added
+// back at each time. The function allocates its own scratch space and is
+// safe for concurrent use.
+func (mc *MonteCarloPricer) pathFunc(in Inputs, times []float64) (int, func(z, path []float64), error) {
+	cash := make([]float64, len(times))
+	factor := make([]float64, len(times))
+	for k, t := range times {
+		cash[k], factor[k] = in.dividendAdjustment(t)
+	}
+	in = in.escrowed()
+
+	if mc.Process == nil {
+		sigma := in.Volatility
+		mu := in.RiskFreeRate - in.DividendYield - 0.5*sigma*sigma
+		var bridge *brownianBridge
+		if mc.BrownianBridge {
+			bridge = newBrownianBridge(times)
+		}
+		return len(times), func(z, path []float64) {
+			if bridge != nil {
+				bridge.path(z, path)
+			} else {
+				// Forward construction: W accumulates scaled increments
+				prevT, prevW := 0.0, 0.0
+				for k, t := range times {
+					prevW += math.Sqrt(t-prevT) * z[k]
+					path[k] = prevW
+					prevT = t
+				}
+			}
+			for k, t := range times {
+				path[k] = (in.Spot*math.Exp(mu*t+sigma*path[k]) + cash[k]) / factor[k]
+			}
+		}, nil
+	}
+
+	p, scheme, err := mc.newProcess(in)
+	if err != nil {
+		return 0, nil, err
+	}
+	grid, err := mc.timeGrid(times[len(times)-1])
+	if err != nil {
+		return 0, nil, err
+	}
+	steps, observed := mergeTimes(times, grid)
+	f := p.Factors()
+	return len(steps) * f, func(z, path []float64) {
+		x := p.Initial()
+		prev := 0.0
+		for k, t := range steps {
+			p.Step(scheme, prev, t-prev, x, z[k*f:(k+1)*f])
+			if i := observed[k]; i >= 0 {
+				path[i] = (x[0] + cash[i]) / factor[i]
+			}
+			prev = t
+		}
+	}, nil
+}
+
+// mergeTimes returns the sorted union of the increasing times a and b and,
+// for each merged time, its index in a or -1 if it only occurs in b. Times
+// within a nanosecond of a year are treated as equal.
+func mergeTimes(a, b []float64) ([]float64, []int) {
+	const eps = 1e-9 / (365 * 24 * 3600)
+	merged := make([]float64, 0, len(a)+len(b))
+	index := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]-eps):
+			merged, index = append(merged, a[i]), append(index, i)
+			i++
+		case i == len(a) || b[j] < a[i]-eps:
+			merged, index = append(merged, b[j]), append(index, -1)
+			j++
+		default:
+			merged, index = append(merged, a[i]), append(index, i)
+			i++
+			j++
+		}
+	}
+	return merged, index
+}
+
+// processSpec simulates a European option on multi-step paths of
+// mc.Process, drawing Factors() normals per step.
+func (mc *MonteCarloPricer) processSpec(in Inputs, optType instrument.OptionType) (simSpec, error) {
+	dim, fill, err := mc.pathFunc(in, []float64{in.Expiry})
+	if err != nil {
+		return simSpec{}, err
+	}
+	return simSpec{
+		dim:   dim,
+		width: 2,
+		sample: func(z []float64, out []float64) {
+			var ST [1]float64
+			fill(z, ST[:])
+			out[0] = vanillaPayoff(optType, ST[0], in.Strike)
+			out[1] = out[0]
+		},
+	}, nil
+}
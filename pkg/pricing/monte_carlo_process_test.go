@@ -0,0 +1,96 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/process"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// mertonPrice is Merton's (1976) series of Black-Scholes prices conditional
+// on the number of jumps, with Poisson weights at intensity lambda(1+k).
+func mertonPrice(in Inputs, optType instrument.OptionType, lambda, m, delta float64) float64 {
+	k := math.Exp(m+0.5*delta*delta) - 1
+	lT := lambda * (1 + k) * in.Expiry
+	price, weight := 0.0, math.Exp(-lT)
+	for n := 0; n < 50; n++ {
+		if n > 0 {
+			weight *= lT / float64(n)
+		}
+		x := in
+		x.Volatility = math.Sqrt(in.Volatility*in.Volatility + float64(n)*delta*delta/in.Expiry)
+		x.RiskFreeRate = in.RiskFreeRate - lambda*k + float64(n)*math.Log(1+k)/in.Expiry
+		price += weight * bsPrice(x, optType)
+	}
+	return price
+}
+
+func TestMonteCarloPricer_MertonMatchesSeries(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+
+	mc := NewMonteCarloPricer(mkt, 200000, 0.05, 0.2)
+	mc.Seed = 3
+	mc.Steps = 1
+	mc.Process = func(in Inputs) process.Process {
+		return process.Merton{S0: in.Spot, Drift: in.RiskFreeRate, Vol: in.Volatility, Lambda: 1, JumpMean: -0.1, JumpVol: 0.2}
+	}
+	res, err := mc.Price(context.Background(), opt)
+	assert.NoError(t, err)
+
+	want := mertonPrice(res.Inputs, instrument.Call, 1, -0.1, 0.2)
+	assert.InDelta(t, want, res.Value(), 4*res.StdErr)
+	assert.Greater(t, want, bsPrice(res.Inputs, instrument.Call))
+}
+
+func TestMonteCarloPricer_HestonWithoutVolOfVolIsBlackScholes(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(95), yearsFrom(now, 1), instrument.Put)
+
+	mc := NewMonteCarloPricer(mkt, 100000, 0.05, 0.2)
+	mc.Seed = 8
+	mc.Steps = 20
+	mc.Process = func(in Inputs) process.Process {
+		v := in.Volatility * in.Volatility
+		return process.Heston{S0: in.Spot, V0: v, Drift: in.RiskFreeRate, Kappa: 2, Theta: v, Rho: -0.5}
+	}
+	res, err := mc.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.InDelta(t, bsPrice(res.Inputs, instrument.Put), res.Value(), 4*res.StdErr)
+
+	// Greeks fall back to bump-and-revalue
+	g, err := mc.Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.InDelta(t, bsGreeks(res.Inputs, instrument.Put).Delta, g.Delta, 0.02)
+
+	mc.Scheme = process.Exact
+	_, err = mc.Price(context.Background(), opt)
+	assert.True(t, errors.Is(err, process.ErrUnsupportedScheme))
+}
+
+func TestMonteCarloPricer_ProcessLSM(t *testing.T) {
+	// Hull: S=50, K=50, r=10%, sigma=40%, T=5 months. American put ~4.28
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 50)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	opt := instrument.NewAmericanOption("AP", underlying, decimal.NewFromInt(50), yearsFrom(now, 5.0/12), instrument.Put)
+
+	mc := NewMonteCarloPricer(mkt, 50000, 0.10, 0.40)
+	mc.Seed = 1
+	mc.Process = GBMProcess
+	res, err := mc.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.Equal(t, ModelLongstaffSchwartz, res.Model)
+	assert.InDelta(t, 4.28, res.Value(), 0.06)
+}
@@ -0,0 +1,110 @@
+package pricing
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultConfidence is the confidence level of the Tolerance interval when
+// MonteCarloPricer.Confidence is zero.
+const DefaultConfidence = 0.95
+
+// Progress is a snapshot of a running Monte Carlo price.
+type Progress struct {
+	// Paths is the number of paths merged into Estimate so far.
+	Paths int
+	// Total is the number of paths requested.
+	Total int
+	// Estimate is the running price and StdErr its standard error.
+	Estimate float64
+	StdErr   float64
+	// Elapsed is the wall-clock time since the run started.
+	Elapsed time.Duration
+}
+
+// ProgressChannel adapts ch for use as MonteCarloPricer.Progress. Updates
+// are dropped rather than stalling the simulation when ch is full.
+func ProgressChannel(ch chan<- Progress) func(Progress) {
+	return func(p Progress) {
+		select {
+		case ch <- p:
+		default:
+		}
+	}
+}
+
+// runMonitor merges batch results in batch order as they complete, reports
+// progress and decides when to stop a run early. Because only the
+// contiguous prefix of finished batches is merged and the stopping rule is
+// checked after each of them, a tolerance stop is reproducible for a seed.
+type runMonitor struct {
+	mu       sync.Mutex
+	plan     batchPlan
+	estimate func(stats []payoffStats, n int) (float64, float64)
+	progress func(Progress)
+	halfMax  float64 // stop once z*StdErr falls below this; zero never
+	z        float64
+	stop     func()
+	start    time.Time
+
+	pending [][]payoffStats
+	next    int // first batch not yet merged
+	paths   int
+	total   []payoffStats
+	done    bool
+}
+
+func newRunMonitor(plan batchPlan, width int) *runMonitor {
+	return &runMonitor{
+		plan:    plan,
+		start:   time.Now(),
+		pending: make([][]payoffStats, plan.count()),
+		total:   make([]payoffStats, width),
+	}
+}
+
+// complete records the statistics of a finished batch.
+func (m *runMonitor) complete(batch int, stats []payoffStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.done {
+		return
+	}
+
+	m.pending[batch] = stats
+	for m.next < len(m.pending) && m.pending[m.next] != nil {
+		for k := range m.total {
+			m.total[k].merge(m.pending[m.next][k])
+		}
+		_, n := m.plan.bounds(m.next)
+		m.paths += n
+		m.pending[m.next] = nil
+		m.next++
+
+		if m.estimate == nil {
+			continue
+		}
+		value, stdErr := m.estimate(m.total, m.paths)
+		if m.progress != nil {
+			m.progress(Progress{
+				Paths:    m.paths,
+				Total:    m.plan.paths,
+				Estimate: value,
+				StdErr:   stdErr,
+				Elapsed:  time.Since(m.start),
+			})
+		}
+		if m.halfMax > 0 && m.paths > 1 && m.z*stdErr <= m.halfMax {
+			m.done = true
+			m.stop()
+			return
+		}
+	}
+}
+
+// result returns the merged statistics and the number of paths behind them.
+func (m *runMonitor) result() ([]payoffStats, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total, m.paths
+}
@@ -0,0 +1,110 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+)
+
+func progressOption() (*market.Snapshot, *instrument.Option) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT1", underlying, decimal.NewFromInt(100), now.Add(365*24*time.Hour), instrument.Call)
+	return mkt, opt
+}
+
+func TestMonteCarloPricer_ReportsProgress(t *testing.T) {
+	mkt, opt := progressOption()
+	pricer := NewMonteCarloPricer(mkt, 10*1024+1, 0.05, 0.20)
+	pricer.Seed = 5
+	var updates []Progress
+	pricer.Progress = func(p Progress) { updates = append(updates, p) }
+
+	res, err := pricer.Price(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(updates) != 11 {
+		t.Fatalf("Expected one update per batch, got %d", len(updates))
+	}
+	for i, p := range updates {
+		if p.Total != pricer.Simulations {
+			t.Errorf("update %d: total %d, want %d", i, p.Total, pricer.Simulations)
+		}
+		if i > 0 && p.Paths <= updates[i-1].Paths {
+			t.Errorf("update %d: paths went from %d to %d", i, updates[i-1].Paths, p.Paths)
+		}
+	}
+	last := updates[len(updates)-1]
+	if last.Paths != pricer.Simulations || last.Estimate != res.Value() || last.StdErr != res.StdErr {
+		t.Errorf("final update %+v does not match result %v ± %v", last, res.Value(), res.StdErr)
+	}
+}
+
+func TestMonteCarloPricer_StopsAtTolerance(t *testing.T) {
+	mkt, opt := progressOption()
+	price := func() PricingResult {
+		pricer := NewMonteCarloPricer(mkt, 1000000, 0.05, 0.20)
+		pricer.Seed = 11
+		pricer.Tolerance = 0.1
+		res, err := pricer.Price(context.Background(), opt)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return res
+	}
+
+	res := price()
+	paths := res.Diagnostics["paths"]
+	if paths >= 1000000 {
+		t.Errorf("Expected an early stop, simulated %v paths", paths)
+	}
+	if half := 1.96 * res.StdErr; half > 0.1 {
+		t.Errorf("Expected half-width <= 0.1, got %v", half)
+	}
+	if again := price(); again.Value() != res.Value() || again.Diagnostics["paths"] != paths {
+		t.Errorf("tolerance stop not reproducible: %v over %v paths vs %v over %v", res.Value(), paths, again.Value(), again.Diagnostics["paths"])
+	}
+}
+
+func TestMonteCarloPricer_StopsAtTimeBudget(t *testing.T) {
+	mkt, opt := progressOption()
+	pricer := NewMonteCarloPricer(mkt, 1<<30, 0.05, 0.20)
+	pricer.TimeBudget = 100 * time.Millisecond
+
+	start := time.Now()
+	res, err := pricer.Price(context.Background(), opt)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("time budget ignored: ran for %v", elapsed)
+	}
+	if paths := res.Diagnostics["paths"]; paths == 0 || paths >= 1<<30 {
+		t.Errorf("Expected a partial run, simulated %v paths", paths)
+	}
+}
+
+func TestProgressChannelDoesNotBlock(t *testing.T) {
+	mkt, opt := progressOption()
+	ch := make(chan Progress, 1)
+	pricer := NewMonteCarloPricer(mkt, 20000, 0.05, 0.20)
+	pricer.Progress = ProgressChannel(ch)
+	if _, err := pricer.Price(context.Background(), opt); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if p := <-ch; p.Paths == 0 {
+		t.Errorf("Expected a progress update, got %+v", p)
+	}
+
+	pricer.Confidence = 1
+	if _, err := pricer.Price(context.Background(), opt); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for confidence 1, got %v", err)
+	}
+}
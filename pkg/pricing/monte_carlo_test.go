@@ -2,10 +2,12 @@ package pricing
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
 	"github.com/shopspring/decimal"
 )
 
@@ -18,15 +20,44 @@ func TestMonteCarloOptionPricing(t *testing.T) {
 
 	// Pricing
 	// Rate=5%, Volatility=20%, Sims=10000
-	pricer := NewMonteCarloPricer(10000, 0.05, 0.20)
-	price, err := pricer.Price(context.Background(), opt)
+	mkt := market.NewSnapshot(time.Now()).SetSpot("AAPL", 100)
+	pricer := NewMonteCarloPricer(mkt, 10000, 0.05, 0.20)
+	res, err := pricer.Price(context.Background(), opt)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if price <= 0 {
-		t.Errorf("Expected positive price, got %f", price)
+	if res.Value() <= 0 {
+		t.Errorf("Expected positive price, got %s", res.Price)
+	}
+	if res.Model != ModelMonteCarlo {
+		t.Errorf("Expected model %q, got %q", ModelMonteCarlo, res.Model)
+	}
+	if res.StdErr <= 0 {
+		t.Errorf("Expected positive standard error, got %f", res.StdErr)
+	}
+}
+
+func TestPricersAreInterchangeable(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	expiry := now.Add(365 * 24 * time.Hour)
+	opt := instrument.NewEuropeanOption("OPT1", underlying, decimal.NewFromInt(100), expiry, instrument.Call)
+
+	pricers := []Pricer{
+		NewBlackScholesPricer(mkt, 0.05, 0.20),
+		NewMonteCarloPricer(mkt, 200000, 0.05, 0.20),
+	}
+	for _, p := range pricers {
+		res, err := p.Price(context.Background(), opt)
+		if err != nil {
+			t.Fatalf("%T: unexpected error %v", p, err)
+		}
+		if math.Abs(res.Value()-10.45) > 0.15 {
+			t.Errorf("%s: expected ~10.45, got %s", res.Model, res.Price)
+		}
 	}
 }
 
@@ -35,7 +66,8 @@ func BenchmarkMonteCarloPricing(b *testing.B) {
 	strike := decimal.NewFromInt(150)
 	expiry := time.Now().Add(30 * 24 * time.Hour)
 	opt := instrument.NewEuropeanOption("OPT1", underlying, strike, expiry, instrument.Call)
-	pricer := NewMonteCarloPricer(10000, 0.05, 0.20)
+	mkt := market.NewSnapshot(time.Now()).SetSpot("AAPL", 100)
+	pricer := NewMonteCarloPricer(mkt, 10000, 0.05, 0.20)
 	ctx := context.Background()
 
 	b.ResetTimer()
@@ -43,3 +75,58 @@ func BenchmarkMonteCarloPricing(b *testing.B) {
 		_, _ = pricer.Price(ctx, opt)
 	}
 }
+
+func TestMonteCarloPricer_SeedIsReproducible(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	expiry := now.Add(365 * 24 * time.Hour)
+	european := instrument.NewEuropeanOption("OPT1", underlying, decimal.NewFromInt(100), expiry, instrument.Call)
+	american := instrument.NewAmericanOption("OPT2", underlying, decimal.NewFromInt(100), expiry, instrument.Put)
+
+	price := func(seed int64, inst instrument.Instrument) PricingResult {
+		pricer := NewMonteCarloPricer(mkt, 20000, 0.05, 0.20)
+		pricer.Seed = seed
+		res, err := pricer.Price(context.Background(), inst)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return res
+	}
+
+	for _, inst := range []instrument.Instrument{european, american} {
+		first, second := price(42, inst), price(42, inst)
+		if first.Value() != second.Value() || first.StdErr != second.StdErr {
+			t.Errorf("%s: same seed gave %v and %v", inst.ID(), first.Price, second.Price)
+		}
+		if other := price(43, inst); other.Value() == first.Value() {
+			t.Errorf("%s: different seeds gave the same price %v", inst.ID(), first.Price)
+		}
+	}
+
+	greeks := func(seed int64) Greeks {
+		pricer := NewMonteCarloPricer(mkt, 20000, 0.05, 0.20)
+		pricer.Seed = seed
+		g, err := pricer.Greeks(context.Background(), european)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return g
+	}
+	if greeks(7) != greeks(7) {
+		t.Error("same seed gave different Greeks")
+	}
+}
+
+func TestStreamSeedsAreDistinct(t *testing.T) {
+	seen := make(map[int64]bool)
+	for _, seed := range []int64{0, 1, 2} {
+		for i := 0; i < 100; i++ {
+			s := streamSeed(seed, i)
+			if seen[s] {
+				t.Fatalf("stream seed collision at seed=%d stream=%d", seed, i)
+			}
+			seen[s] = true
+		}
+	}
+}
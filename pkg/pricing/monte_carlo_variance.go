@@ -0,0 +1,162 @@
+package pricing
+
+import (
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+)
+
+// VarianceReduction is a set of Monte Carlo variance reduction techniques.
+type VarianceReduction uint
+
+const (
+	// Antithetic pairs every normal draw z with its mirror -z and averages
+	// the two payoffs, so each simulation evaluates two paths.
+	Antithetic VarianceReduction = 1 << iota
+	// ControlVariate regresses the payoff on a quantity of the same path
+	// whose mean is known exactly: the terminal spot for vanilla options,
+	// and the vanilla payoff or a geometric average for exotic ones.
+	ControlVariate
+	// MomentMatching rescales each batch's normals to have exactly zero
+	// mean and unit variance.
+	MomentMatching
+	// ImportanceSampling shifts the normals so that out-of-the-money
+	// options finish in the money about half the time, reweighting each
+	// path by its likelihood ratio.
+	ImportanceSampling
+)
+
+// Has reports whether every technique in t is selected.
+func (v VarianceReduction) Has(t VarianceReduction) bool {
+	return v&t == t
+}
+
+// normals returns a function yielding the standard normals of path j out
+// of n drawn from stream. The returned slice is reused between calls.
+func (mc *MonteCarloPricer) normals(stream NormalStream, dim, n int) func(j int) []float64 {
+	if !mc.VarianceReduction.Has(MomentMatching) || n < 2 {
+		z := make([]float64, dim)
+		return func(int) []float64 {
+			stream.Next(z)
+			return z
+		}
+	}
+
+	block := make([][]float64, n)
+	for j := range block {
+		block[j] = make([]float64, dim)
+		stream.Next(block[j])
+	}
+	for k := 0; k < dim; k++ {
+		var stats payoffStats
+		for j := range block {
+			stats.add(block[j][k])
+		}
+		mean := stats.sum / float64(n)
+		std := math.Sqrt((stats.sumSq - float64(n)*mean*mean) / float64(n-1))
+		for j := range block {
+			block[j][k] = (block[j][k] - mean) / std
+		}
+	}
+	return func(j int) []float64 {
+		return block[j]
+	}
+}
+
+// pathEvaluator applies importance sampling and antithetic pairing around a
+// sampler.
+type pathEvaluator struct {
+	spec       simSpec
+	antithetic bool
+	x          []float64
+	out        []float64
+	mirror     []float64
+}
+
+func newPathEvaluator(spec simSpec, antithetic bool) *pathEvaluator {
+	return &pathEvaluator{
+		spec:       spec,
+		antithetic: antithetic,
+		x:          make([]float64, spec.dim),
+		out:        make([]float64, spec.width),
+		mirror:     make([]float64, spec.width),
+	}
+}
+
+// eval returns the estimators for the normal draws z.
+func (p *pathEvaluator) eval(z []float64) []float64 {
+	p.sampleInto(z, 1, p.out)
+	if !p.antithetic {
+		return p.out
+	}
+	p.sampleInto(z, -1, p.mirror)
+	for k := range p.out {
+		p.out[k] = 0.5 * (p.out[k] + p.mirror[k])
+	}
+	return p.out
+}
+
+// sampleInto evaluates the sampler at sign*z, shifted and reweighted when
+// importance sampling is active.
+func (p *pathEvaluator) sampleInto(z []float64, sign float64, out []float64) {
+	weight := 1.0
+	if p.spec.shift == nil {
+		for k := range z {
+			p.x[k] = sign * z[k]
+		}
+	} else {
+		// x ~ N(theta, 1) has likelihood ratio exp(-theta.x + |theta|^2/2)
+		logW := 0.0
+		for k, theta := range p.spec.shift {
+			p.x[k] = sign*z[k] + theta
+			logW += -theta*p.x[k] + 0.5*theta*theta
+		}
+		weight = math.Exp(logW)
+	}
+
+	p.spec.sample(p.x, out)
+	if weight != 1 {
+		for k := range out {
+			out[k] *= weight
+		}
+	}
+}
+
+// importanceShift returns the mean shift that centres the terminal spot of
+// an out-of-the-money vanilla option on its strike, or nil when importance
+// sampling is off or the option is in the money.
+func (mc *MonteCarloPricer) importanceShift(in Inputs, optType instrument.OptionType) []float64 {
+	if !mc.VarianceReduction.Has(ImportanceSampling) {
+		return nil
+	}
+	drift := (in.RiskFreeRate - in.DividendYield - 0.5*in.Volatility*in.Volatility) * in.Expiry
+	theta := (math.Log(in.Strike/in.Spot) - drift) / (in.Volatility * math.Sqrt(in.Expiry))
+	if (optType == instrument.Call && theta <= 0) || (optType == instrument.Put && theta >= 0) {
+		return nil
+	}
+	return []float64{theta}
+}
+
+// controlVariateMean returns the control-variate estimate of the mean of y
+// over n draws, given a control x with known mean, and its standard error.
+// x must have been accumulated with y as its primary estimator.
+func controlVariateMean(y, x payoffStats, mean float64, n int) (float64, float64) {
+	if n < 2 {
+		return y.meanStdErr(n)
+	}
+	N := float64(n)
+	yBar, xBar := y.sum/N, x.sum/N
+	varX := (x.sumSq - N*xBar*xBar) / (N - 1)
+	varY := (y.sumSq - N*yBar*yBar) / (N - 1)
+	cov := (x.sumCross - N*xBar*yBar) / (N - 1)
+	if varX <= 0 {
+		return y.meanStdErr(n)
+	}
+
+	beta := cov / varX
+	residual := varY - cov*cov/varX
+	if residual < 0 {
+		residual = 0
+	}
+	return yBar - beta*(xBar-mean), math.Sqrt(residual / N)
+}
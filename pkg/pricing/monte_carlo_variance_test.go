@@ -0,0 +1,96 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonteCarloPricer_VarianceReduction(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	expiry := yearsFrom(now, 1)
+
+	tests := []struct {
+		name   string
+		strike int64
+		vr     VarianceReduction
+	}{
+		{name: "antithetic", strike: 100, vr: Antithetic},
+		{name: "control variate", strike: 100, vr: ControlVariate},
+		{name: "moment matching", strike: 100, vr: MomentMatching},
+		{name: "importance sampling", strike: 160, vr: ImportanceSampling},
+		{name: "combined", strike: 160, vr: Antithetic | MomentMatching | ImportanceSampling},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(tt.strike), expiry, instrument.Call)
+			want, err := NewBlackScholesPricer(mkt, 0.05, 0.2).Price(context.Background(), opt)
+			assert.NoError(t, err)
+
+			plain := NewMonteCarloPricer(mkt, 50000, 0.05, 0.2)
+			plain.Seed = 11
+			base, err := plain.Price(context.Background(), opt)
+			assert.NoError(t, err)
+
+			reduced := NewMonteCarloPricer(mkt, 50000, 0.05, 0.2)
+			reduced.Seed = 11
+			reduced.VarianceReduction = tt.vr
+			got, err := reduced.Price(context.Background(), opt)
+			assert.NoError(t, err)
+
+			assert.InDelta(t, want.Value(), got.Value(), 4*base.StdErr)
+			if tt.vr != MomentMatching {
+				assert.Less(t, got.StdErr, base.StdErr)
+			}
+		})
+	}
+}
+
+func TestMonteCarloPricer_ControlVariateOnVanilla(t *testing.T) {
+	// The terminal spot is a control distinct from the payoff: the estimate
+	// keeps its sampling error, but far less of it
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(90), yearsFrom(now, 0.5), instrument.Call)
+
+	want, err := NewBlackScholesPricer(mkt, 0.05, 0.2).Price(context.Background(), opt)
+	assert.NoError(t, err)
+
+	mc := NewMonteCarloPricer(mkt, 20000, 0.05, 0.2)
+	mc.Seed = 17
+	plain, err := mc.Price(context.Background(), opt)
+	assert.NoError(t, err)
+
+	mc.VarianceReduction = ControlVariate
+	got, err := mc.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.Greater(t, got.StdErr, 0.0)
+	assert.Less(t, got.StdErr, plain.StdErr/2)
+	assert.InDelta(t, want.Value(), got.Value(), 4*got.StdErr)
+}
+
+func TestMonteCarloGreeks_WithVarianceReduction(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+
+	want, err := NewBlackScholesPricer(mkt, 0.05, 0.2).Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+
+	mc := NewMonteCarloPricer(mkt, 100000, 0.05, 0.2)
+	mc.Seed = 5
+	mc.VarianceReduction = Antithetic | MomentMatching
+	got, err := mc.Greeks(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.InDelta(t, want.Delta, got.Delta, 0.005)
+	assert.InDelta(t, want.Vega, got.Vega, 0.5)
+}
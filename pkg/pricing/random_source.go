@@ -0,0 +1,91 @@
+package pricing
+
+import (
+	"math"
+	"math/rand"
+)
+
+// RandomSource generates the standard normal draws that drive simulated
+// paths. MonteCarloPricer splits the paths of a run into contiguous blocks
+// and asks the source for one stream per block.
+type RandomSource interface {
+	// NewStream returns the draws of paths first, first+1, ... of a run,
+	// dim normals per path. Pseudo-random sources derive the stream from
+	// seed and stream alone; low-discrepancy sources index their sequence
+	// by path number and use seed only to scramble it.
+	NewStream(seed int64, stream, first, dim int) NormalStream
+}
+
+// NormalStream yields the draws of successive paths.
+type NormalStream interface {
+	// Next fills z with the standard normals of the next path.
+	Next(z []float64)
+}
+
+// PseudoRandom draws independent normals from math/rand. It is the default
+// source of MonteCarloPricer.
+type PseudoRandom struct{}
+
+// NewStream returns stream number stream of seed.
+func (PseudoRandom) NewStream(seed int64, stream, _, _ int) NormalStream {
+	return pseudoStream{rand.New(rand.NewSource(streamSeed(seed, stream)))}
+}
+
+type pseudoStream struct {
+	rng *rand.Rand
+}
+
+func (s pseudoStream) Next(z []float64) {
+	for k := range z {
+		z[k] = s.rng.NormFloat64()
+	}
+}
+
+// uniformStream yields points of a low-discrepancy sequence in (0,1)^dim.
+type uniformStream interface {
+	next(u []float64)
+}
+
+// quasiNormalStream maps a uniform sequence to normals by inversion, which
+// preserves the low discrepancy of the points.
+type quasiNormalStream struct {
+	points uniformStream
+	u      []float64
+}
+
+func newQuasiNormalStream(points uniformStream, dim int) *quasiNormalStream {
+	return &quasiNormalStream{points: points, u: make([]float64, dim)}
+}
+
+func (s *quasiNormalStream) Next(z []float64) {
+	s.points.next(s.u)
+	for k, u := range s.u {
+		z[k] = normInv(u)
+	}
+}
+
+// normInv is the inverse of the standard normal cumulative distribution.
+// math.Erfcinv computes Erfinv(1-x) and loses precision in the far tails, so
+// the estimate is polished by Newton steps on the tail probability.
+func normInv(u float64) float64 {
+	if u > 0.5 {
+		return -normInv(1 - u)
+	}
+	x := -math.Sqrt2 * math.Erfcinv(2*u)
+	for i := 0; i < 2 && u < 1e-6; i++ {
+		x -= (0.5*math.Erfc(-x/math.Sqrt2) - u) / normPdf(x)
+	}
+	return x
+}
+
+// splitMix64 is a small deterministic generator used to derive scrambling
+// keys and direction numbers from a seed.
+type splitMix64 uint64
+
+func (s *splitMix64) next() uint64 {
+	*s += 0x9e3779b97f4a7c15
+	z := uint64(*s)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,147 @@
+package pricing
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrimitivePolynomialsFollowJoeKuoOrder(t *testing.T) {
+	// Degree s and interior coefficients a of the first Joe-Kuo dimensions
+	want := []struct{ s, a uint64 }{
+		{1, 0}, {2, 1}, {3, 1}, {3, 2}, {4, 1}, {4, 4},
+		{5, 2}, {5, 4}, {5, 7}, {5, 11}, {5, 13}, {5, 14},
+		{6, 1}, {6, 13}, {6, 16}, {6, 19}, {6, 22}, {6, 25}, {7, 1}, {7, 4},
+	}
+	var polys []uint64
+	for _, w := range want {
+		p := nextPrimitivePolynomial(polys)
+		polys = append(polys, p)
+		assert.Equal(t, 1<<w.s|w.a<<1|1, p)
+	}
+	for j, m := range sobolInitial {
+		for k, mk := range m {
+			assert.Equal(t, uint32(1), mk&1, "dimension %d m_%d must be odd", j+2, k+1)
+			assert.Less(t, mk, uint32(1)<<(k+1), "dimension %d m_%d too large", j+2, k+1)
+		}
+	}
+}
+
+func TestSobolPoints(t *testing.T) {
+	// First points of the two-dimensional Sobol sequence after the origin
+	want := [][2]float64{{0.5, 0.5}, {0.75, 0.25}, {0.25, 0.75}, {0.375, 0.375}, {0.875, 0.875}}
+	stream := Sobol{}.NewStream(0, 0, 0, 2).(*quasiNormalStream)
+	z := make([]float64, 2)
+	for _, w := range want {
+		stream.Next(z)
+		assert.InDelta(t, w[0], stream.u[0], 1e-9)
+		assert.InDelta(t, w[1], stream.u[1], 1e-9)
+	}
+
+	// Streams can start anywhere in the sequence
+	for _, scrambling := range []Scrambling{NoScrambling, DigitalShift, OwenScrambling} {
+		full := Sobol{Scrambling: scrambling}.NewStream(9, 0, 0, 30)
+		jumped := Sobol{Scrambling: scrambling}.NewStream(9, 3, 37, 30)
+		a, b := make([]float64, 30), make([]float64, 30)
+		for i := 0; i <= 37; i++ {
+			full.Next(a)
+		}
+		jumped.Next(b)
+		assert.Equal(t, a, b)
+	}
+}
+
+func TestHaltonPoints(t *testing.T) {
+	assert.Equal(t, []uint64{2, 3, 5, 7, 11}, firstPrimes(5))
+	assert.InDeltaSlice(t, []float64{0.5, 0.25, 0.75, 0.125}, []float64{
+		radicalInverse(1, 2), radicalInverse(2, 2), radicalInverse(3, 2), radicalInverse(4, 2),
+	}, 1e-15)
+	assert.InDeltaSlice(t, []float64{1.0 / 3, 2.0 / 3, 1.0 / 9}, []float64{
+		radicalInverse(1, 3), radicalInverse(2, 3), radicalInverse(3, 3),
+	}, 1e-15)
+}
+
+func TestNormInv(t *testing.T) {
+	for _, x := range []float64{-8, -3, -1, 0, 0.5, 2, 7} {
+		// Erfc keeps full precision in both tails, unlike normCdf
+		u := 0.5 * math.Erfc(-x/math.Sqrt2)
+		if x > 0 {
+			u = 1 - 0.5*math.Erfc(x/math.Sqrt2)
+		}
+		assert.InDelta(t, x, normInv(u), 1e-6*math.Max(1, math.Abs(x)))
+	}
+}
+
+func TestBrownianBridgeCovariance(t *testing.T) {
+	// The bridge is linear in z, so feeding unit vectors recovers its
+	// matrix A; A A^T must be the Brownian covariance min(s, t).
+	times := []float64{0.1, 0.25, 0.3, 0.5, 0.8, 1.0, 1.7}
+	n := len(times)
+	b := newBrownianBridge(times)
+	cols := make([][]float64, n)
+	for i := range cols {
+		z := make([]float64, n)
+		z[i] = 1
+		cols[i] = make([]float64, n)
+		b.path(z, cols[i])
+	}
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			cov := 0.0
+			for i := range cols {
+				cov += cols[i][r] * cols[i][c]
+			}
+			assert.InDelta(t, math.Min(times[r], times[c]), cov, 1e-12)
+		}
+	}
+}
+
+func TestMonteCarloPricer_QuasiRandomSources(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT", underlying, decimal.NewFromInt(100), yearsFrom(now, 1), instrument.Call)
+	want, err := NewBlackScholesPricer(mkt, 0.05, 0.2).Price(context.Background(), opt)
+	assert.NoError(t, err)
+
+	sources := map[string]RandomSource{
+		"sobol":         Sobol{},
+		"sobol-shift":   Sobol{Scrambling: DigitalShift},
+		"sobol-owen":    Sobol{Scrambling: OwenScrambling},
+		"halton":        Halton{},
+		"halton-random": Halton{RandomShift: true},
+	}
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			mc := NewMonteCarloPricer(mkt, 20000, 0.05, 0.2)
+			mc.Seed = 3
+			mc.Source = source
+			got, err := mc.Price(context.Background(), opt)
+			assert.NoError(t, err)
+			// Pseudo-random sampling error at this size is ~0.1
+			assert.InDelta(t, want.Value(), got.Value(), 0.02)
+		})
+	}
+}
+
+func TestMonteCarloPricer_SobolBrownianBridgeLSM(t *testing.T) {
+	// Hull: S=50, K=50, r=10%, sigma=40%, T=5 months. American put ~4.28
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 50)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	opt := instrument.NewAmericanOption("AP", underlying, decimal.NewFromInt(50), yearsFrom(now, 5.0/12), instrument.Put)
+
+	mc := NewMonteCarloPricer(mkt, 50000, 0.10, 0.40)
+	mc.Seed = 1
+	mc.Source = Sobol{Scrambling: OwenScrambling}
+	mc.BrownianBridge = true
+	res, err := mc.Price(context.Background(), opt)
+	assert.NoError(t, err)
+	assert.InDelta(t, 4.28, res.Value(), 0.05)
+}
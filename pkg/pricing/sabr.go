@@ -0,0 +1,226 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+)
+
+// SABRExpansion selects which of Hagan's implied volatility expansions a
+// SABR smile gives.
+type SABRExpansion string
+
+const (
+	// SABRLognormal gives Black volatilities, for Black76Pricer and the
+	// other lognormal pricers.
+	SABRLognormal SABRExpansion = "lognormal"
+	// SABRNormal gives normal volatilities, for BachelierPricer, and
+	// allows zero or negative forwards and strikes when Beta is zero.
+	SABRNormal SABRExpansion = "normal"
+)
+
+// SABRParams are the parameters of the SABR model of Hagan et al. (2002)
+//
+//	dF = alpha F^Beta dW1
+//	d alpha = Nu alpha dW2,  dW1 dW2 = Rho dt
+type SABRParams struct {
+	Alpha float64 // initial volatility
+	Beta  float64 // backbone exponent, between 0 (normal) and 1 (lognormal)
+	Rho   float64 // forward-volatility correlation
+	Nu    float64 // volatility of volatility
+}
+
+// Validate checks that the parameters define a SABR model.
+func (p SABRParams) Validate() error {
+	if err := positive("sabr alpha", p.Alpha); err != nil {
+		return err
+	}
+	if math.IsNaN(p.Beta) || p.Beta < 0 || p.Beta > 1 {
+		return &InputError{Field: "sabr beta", Value: p.Beta}
+	}
+	if math.IsNaN(p.Rho) || p.Rho <= -1 || p.Rho >= 1 {
+		return &InputError{Field: "sabr rho", Value: p.Rho}
+	}
+	if math.IsNaN(p.Nu) || math.IsInf(p.Nu, 0) || p.Nu < 0 {
+		return &InputError{Field: "sabr nu", Value: p.Nu}
+	}
+	return nil
+}
+
+// SABRLognormalVol returns Hagan's expansion of the Black volatility of an
+// option struck at strike on forward, expiring in expiry years. The
+// forward and strike must be positive.
+func SABRLognormalVol(p SABRParams, forward, strike, expiry float64) float64 {
+	F, K, b := forward, strike, 1-p.Beta
+	logFK := math.Log(F / K)
+	fk := math.Pow(F*K, b/2)
+	z := p.Nu / p.Alpha * fk * logFK
+	denom := fk * (1 + b*b/24*logFK*logFK + b*b*b*b/1920*logFK*logFK*logFK*logFK)
+	correction := 1 + (b*b/24*p.Alpha*p.Alpha/(fk*fk)+p.Rho*p.Beta*p.Nu*p.Alpha/(4*fk)+(2-3*p.Rho*p.Rho)/24*p.Nu*p.Nu)*expiry
+	return p.Alpha / denom * sabrRatio(z, p.Rho) * correction
+}
+
+// SABRNormalVol returns Hagan's expansion of the normal volatility of an
+// option struck at strike on forward, expiring in expiry years. With a
+// positive Beta the forward and strike must be positive.
+func SABRNormalVol(p SABRParams, forward, strike, expiry float64) float64 {
+	F, K, beta := forward, strike, p.Beta
+	// The backbone is taken at the geometric mean of the forward and strike
+	mid := 1.0
+	if beta > 0 {
+		mid = math.Sqrt(F * K)
+	}
+	midBeta := math.Pow(mid, beta)
+
+	// (F - K) / Int_K^F u^-Beta du, which tends to mid^Beta at the money
+	scale := midBeta
+	if beta > 0 && math.Abs(F-K) > 1e-6*mid {
+		switch {
+		case beta == 1:
+			scale = (F - K) / math.Log(F/K)
+		default:
+			scale = (F - K) * (1 - beta) / (math.Pow(F, 1-beta) - math.Pow(K, 1-beta))
+		}
+	}
+	zeta := p.Nu / p.Alpha * (F - K) / midBeta
+	fb := mid / midBeta // mid^(1-Beta)
+	correction := 1 + (-beta*(2-beta)*p.Alpha*p.Alpha/(24*fb*fb)+
+		p.Rho*p.Alpha*p.Nu*beta/(4*fb)+(2-3*p.Rho*p.Rho)/24*p.Nu*p.Nu)*expiry
+	return p.Alpha * scale * sabrRatio(zeta, p.Rho) * correction
+}
+
+// sabrRatio returns z / x(z) with x(z) = ln((sqrt(1 - 2 rho z + z^2) + z - rho) / (1 - rho)),
+// which tends to one as z does to zero.
+func sabrRatio(z, rho float64) float64 {
+	if math.Abs(z) < 1e-7 {
+		return 1 - rho*z/2
+	}
+	x := math.Log((math.Sqrt(1-2*rho*z+z*z) + z - rho) / (1 - rho))
+	return z / x
+}
+
+// SABRSmile is the SABR smile of a forward. As a volatility surface it
+// gives the volatility of its Expansion at each strike, and at each expiry
+// through the expansion's time dependence; a normal smile is recorded in
+// a snapshot with SetNormalVolSurface.
+type SABRSmile struct {
+	Params    SABRParams
+	Forward   float64
+	Expansion SABRExpansion
+}
+
+var _ market.VolSurface = SABRSmile{}
+
+// Volatility returns the smile's implied volatility at expiry and strike.
+func (s SABRSmile) Volatility(expiry, strike float64) (float64, error) {
+	if err := positive("expiry", expiry); err != nil {
+		return 0, err
+	}
+	if err := s.validateStrike(strike); err != nil {
+		return 0, err
+	}
+	if s.Expansion == SABRNormal {
+		return SABRNormalVol(s.Params, s.Forward, strike, expiry), nil
+	}
+	return SABRLognormalVol(s.Params, s.Forward, strike, expiry), nil
+}
+
+// validateStrike checks that strike is in the domain of the smile's
+// expansion.
+func (s SABRSmile) validateStrike(strike float64) error {
+	if s.Expansion == SABRNormal && s.Params.Beta == 0 {
+		return finite("strike", strike)
+	}
+	return positive("strike", strike)
+}
+
+// SABRCalibration is the outcome of fitting a SABR smile to quotes.
+type SABRCalibration struct {
+	Smile SABRSmile
+	// RMSE is the root mean square of the weighted volatility errors.
+	RMSE       float64
+	Iterations int
+}
+
+// CalibrateSABR fits Alpha, Rho and Nu of a SABR smile with the given Beta
+// to volatilities quoted in expansion on options on forward expiring in
+// expiry years, by Levenberg-Marquardt least squares. As is market
+// practice, Beta is chosen rather than fitted.
+func CalibrateSABR(ctx context.Context, forward, expiry, beta float64, expansion SABRExpansion, quotes []VolQuote) (SABRCalibration, error) {
+	if len(quotes) < 3 {
+		return SABRCalibration{}, fmt.Errorf("%w: %d quotes for 3 SABR parameters", ErrInvalidInput, len(quotes))
+	}
+	switch expansion {
+	case SABRLognormal, SABRNormal:
+	default:
+		return SABRCalibration{}, fmt.Errorf("%w: unknown SABR expansion %q", ErrInvalidInput, expansion)
+	}
+	if err := positive("expiry", expiry); err != nil {
+		return SABRCalibration{}, err
+	}
+	smile := SABRSmile{Params: SABRParams{Alpha: 1, Beta: beta}, Forward: forward, Expansion: expansion}
+	if err := smile.Params.Validate(); err != nil {
+		return SABRCalibration{}, err
+	}
+	if err := smile.validateStrike(forward); err != nil {
+		return SABRCalibration{}, &InputError{Field: "forward", Value: forward}
+	}
+	nearest := math.Inf(1)
+	var atmVol float64
+	for _, q := range quotes {
+		if err := smile.validateStrike(q.Strike); err != nil {
+			return SABRCalibration{}, err
+		}
+		if err := positive("quoted volatility", q.Vol); err != nil {
+			return SABRCalibration{}, err
+		}
+		if err := finite("weight", q.Weight); err != nil {
+			return SABRCalibration{}, err
+		}
+		if d := math.Abs(q.Strike - forward); d < nearest {
+			nearest, atmVol = d, q.Vol
+		}
+	}
+
+	residuals := func(x, r []float64) {
+		s := smile
+		s.Params = sabrFromUnconstrained(x, beta)
+		for i, q := range quotes {
+			vol, _ := s.Volatility(expiry, q.Strike)
+			r[i] = q.weight() * (vol - q.Vol)
+		}
+	}
+	// At the money alpha F^(Beta-1) is the Black volatility and alpha F^Beta
+	// the normal one
+	start := SABRParams{Alpha: atmVol * math.Pow(forward, 1-beta), Beta: beta, Nu: 0.5}
+	if expansion == SABRNormal {
+		start.Alpha = atmVol
+		if beta > 0 {
+			start.Alpha /= math.Pow(forward, beta)
+		}
+	}
+	x, iters, err := levenbergMarquardt(ctx, residuals, len(quotes), sabrToUnconstrained(start), 200)
+	if err != nil {
+		return SABRCalibration{}, err
+	}
+
+	r := make([]float64, len(quotes))
+	residuals(x, r)
+	smile.Params = sabrFromUnconstrained(x, beta)
+	return SABRCalibration{
+		Smile:      smile,
+		RMSE:       math.Sqrt(sumSquares(r) / float64(len(r))),
+		Iterations: iters,
+	}, nil
+}
+
+// sabrToUnconstrained maps Alpha, Rho and Nu to R^3 (logs and atanh).
+func sabrToUnconstrained(p SABRParams) []float64 {
+	return []float64{math.Log(p.Alpha), math.Atanh(p.Rho), math.Log(p.Nu)}
+}
+
+func sabrFromUnconstrained(x []float64, beta float64) SABRParams {
+	return SABRParams{Alpha: math.Exp(x[0]), Beta: beta, Rho: math.Tanh(x[1]), Nu: math.Exp(x[2])}
+}
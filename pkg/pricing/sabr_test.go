@@ -0,0 +1,102 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSABRVol_Limits(t *testing.T) {
+	// Without volatility of volatility a lognormal backbone is Black-Scholes
+	// and a normal one Bachelier
+	assert.InDelta(t, 0.25, SABRLognormalVol(SABRParams{Alpha: 0.25, Beta: 1}, 100, 120, 2), 1e-12)
+	assert.InDelta(t, 0.01, SABRNormalVol(SABRParams{Alpha: 0.01}, 0.02, -0.01, 2), 1e-12)
+
+	// The volatility is continuous through the money
+	p := SABRParams{Alpha: 0.04, Beta: 0.5, Rho: -0.3, Nu: 0.4}
+	for _, vol := range []func(SABRParams, float64, float64, float64) float64{SABRLognormalVol, SABRNormalVol} {
+		assert.InDelta(t, vol(p, 0.03, 0.03, 1), vol(p, 0.03, 0.03*(1+1e-9), 1), 1e-9)
+	}
+}
+
+func TestSABRVol_ExpansionsAgree(t *testing.T) {
+	// Both expansions approximate the same model, so Black-76 on the
+	// lognormal volatility and Bachelier on the normal one agree closely
+	// over short expiries
+	p := SABRParams{Alpha: 0.035, Beta: 0.5, Rho: -0.25, Nu: 0.45}
+	const F, T = 0.03, 0.5
+	for _, K := range []float64{0.015, 0.025, 0.03, 0.035, 0.05} {
+		in := Inputs{Spot: F, Strike: K, Expiry: T, RiskFreeRate: 0.02}
+		in.Volatility = SABRLognormalVol(p, F, K, T)
+		black := black76Price(in, instrument.Call)
+		in.Volatility = SABRNormalVol(p, F, K, T)
+		normal := bachelierPrice(in, instrument.Call)
+		assert.InDelta(t, black, normal, 5e-7, "strike %g", K)
+	}
+}
+
+func TestCalibrateSABR_RecoversSmile(t *testing.T) {
+	cases := map[string]struct {
+		want      SABRParams
+		forward   float64
+		strikes   []float64
+		expansion SABRExpansion
+	}{
+		"lognormal": {SABRParams{Alpha: 0.3, Beta: 0.7, Rho: -0.35, Nu: 0.6}, 0.04,
+			[]float64{0.02, 0.03, 0.035, 0.04, 0.045, 0.05, 0.07}, SABRLognormal},
+		"normal around zero": {SABRParams{Alpha: 0.008, Rho: 0.2, Nu: 0.5}, -0.002,
+			[]float64{-0.012, -0.007, -0.002, 0.003, 0.008, 0.013}, SABRNormal},
+	}
+	for name, c := range cases {
+		smile := SABRSmile{Params: c.want, Forward: c.forward, Expansion: c.expansion}
+		var quotes []VolQuote
+		for _, K := range c.strikes {
+			vol, err := smile.Volatility(5, K)
+			assert.NoError(t, err, name)
+			quotes = append(quotes, VolQuote{Strike: K, Vol: vol})
+		}
+
+		cal, err := CalibrateSABR(context.Background(), c.forward, 5, c.want.Beta, c.expansion, quotes)
+		assert.NoError(t, err, name)
+		assert.Less(t, cal.RMSE, 1e-7, name)
+		assert.InDelta(t, c.want.Alpha, cal.Smile.Params.Alpha, 1e-4*c.want.Alpha, name)
+		assert.InDelta(t, c.want.Rho, cal.Smile.Params.Rho, 1e-3, name)
+		assert.InDelta(t, c.want.Nu, cal.Smile.Params.Nu, 1e-3, name)
+		assert.Equal(t, c.expansion, cal.Smile.Expansion)
+	}
+
+	_, err := CalibrateSABR(context.Background(), -0.01, 1, 0.5, SABRLognormal, []VolQuote{{Strike: 0.01, Vol: 0.2}, {Strike: 0.02, Vol: 0.2}, {Strike: 0.03, Vol: 0.2}})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	_, err = CalibrateSABR(context.Background(), 0.02, 1, 0.5, "cubic", []VolQuote{{Strike: 0.01, Vol: 0.2}, {Strike: 0.02, Vol: 0.2}, {Strike: 0.03, Vol: 0.2}})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestSABRSmile_PricesAsVolSurface(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	params := SABRParams{Alpha: 0.009, Rho: -0.2, Nu: 0.4}
+	normal := SABRSmile{Params: params, Forward: 0.01, Expansion: SABRNormal}
+	lognormal := SABRSmile{Params: SABRParams{Alpha: 0.2, Beta: 1, Rho: -0.2, Nu: 0.4}, Forward: 0.01}
+	mkt := market.NewSnapshot(now).SetSpot("EUR6M", 0.01).SetNormalVolatility("EUR6M", 0.02).
+		SetNormalVolSurface("EUR6M", normal).SetVolSurface("EUR6M", lognormal)
+	fwd := instrument.NewFuture("EUR6MZ5", "EUR", "EUR6M", yearsFrom(now, 1.5))
+
+	for _, K := range []float64{-0.005, 0.01, 0.02} {
+		opt := instrument.NewEuropeanOption("C", fwd, decimal.NewFromFloat(K), yearsFrom(now, 1), instrument.Call)
+		res, err := NewBachelierPricer(mkt, 0.02, 0.01).Price(context.Background(), opt)
+		assert.NoError(t, err)
+		want, _ := normal.Volatility(res.Inputs.Expiry, K)
+		assert.InDelta(t, want, res.Inputs.Volatility, 1e-12, "the normal surface over the flat quote")
+	}
+
+	// Black-76 reads the lognormal smile
+	opt := instrument.NewEuropeanOption("C", fwd, decimal.NewFromFloat(0.015), yearsFrom(now, 1), instrument.Call)
+	res, err := NewBlack76Pricer(mkt, 0.02, 0.5).Price(context.Background(), opt)
+	assert.NoError(t, err)
+	want, _ := lognormal.Volatility(res.Inputs.Expiry, 0.015)
+	assert.InDelta(t, want, res.Inputs.Volatility, 1e-12)
+}
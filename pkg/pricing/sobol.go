@@ -0,0 +1,249 @@
+package pricing
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Scrambling randomises a Sobol sequence while keeping its low discrepancy.
+type Scrambling int
+
+const (
+	// NoScrambling uses the raw Sobol points; seeds are ignored.
+	NoScrambling Scrambling = iota
+	// DigitalShift XORs every coordinate with a random key per dimension.
+	DigitalShift
+	// OwenScrambling applies a hash-based nested uniform scramble
+	// (Burley, 2020) to every coordinate.
+	OwenScrambling
+)
+
+// Sobol is a Sobol low-discrepancy RandomSource. Path p of a run uses point
+// p+1 of the sequence, skipping the origin, so a run of N paths consumes
+// the first N points whatever the number of workers.
+type Sobol struct {
+	Scrambling Scrambling
+}
+
+// NewStream returns the points of paths first, first+1, ... The stream
+// number is ignored so that blocks of one run share a single sequence.
+func (s Sobol) NewStream(seed int64, _, first, dim int) NormalStream {
+	v := sobolDirections(dim)
+	keys := make([]uint32, dim)
+	if s.Scrambling != NoScrambling {
+		rng := splitMix64(uint64(seed))
+		for k := range keys {
+			keys[k] = uint32(rng.next())
+		}
+	}
+
+	st := &sobolStream{v: v, keys: keys, scrambling: s.Scrambling, n: uint64(first) + 1, x: make([]uint32, dim)}
+	// Jump to point n: its Gray code selects the direction numbers to XOR
+	g := st.n ^ (st.n >> 1)
+	for k := range st.x {
+		for b := 0; g>>b != 0; b++ {
+			if g>>b&1 == 1 {
+				st.x[k] ^= v[k][b]
+			}
+		}
+	}
+	return newQuasiNormalStream(st, dim)
+}
+
+type sobolStream struct {
+	v          [][32]uint32
+	keys       []uint32
+	scrambling Scrambling
+	n          uint64
+	x          []uint32
+}
+
+func (s *sobolStream) next(u []float64) {
+	for k, x := range s.x {
+		switch s.scrambling {
+		case DigitalShift:
+			x ^= s.keys[k]
+		case OwenScrambling:
+			x = owenScramble(x, s.keys[k])
+		}
+		u[k] = (float64(x) + 0.5) / (1 << 32)
+	}
+
+	// Gray-code update to point n+1
+	c := bits.TrailingZeros64(s.n + 1)
+	for k := range s.x {
+		s.x[k] ^= s.v[k][c]
+	}
+	s.n++
+}
+
+// owenScramble is the Laine-Karras hash applied in reversed bit order, an
+// inexpensive nested uniform scramble of the binary digits of x.
+func owenScramble(x, seed uint32) uint32 {
+	x = bits.Reverse32(x)
+	x += seed
+	x ^= x * 0x6c50b47c
+	x ^= x * 0xb82f1e52
+	x ^= x * 0xc7afe638
+	x ^= x * 0x8d22f6e6
+	return bits.Reverse32(x)
+}
+
+// sobolInitial holds the initial direction numbers m_1..m_s of dimensions
+// 2 to 21, from the Joe and Kuo (2008) tables. Higher dimensions draw odd
+// m_k < 2^k from a fixed generator, which keeps the digital net valid.
+var sobolInitial = [][]uint32{
+	{1},
+	{1, 3},
+	{1, 3, 1},
+	{1, 1, 1},
+	{1, 1, 3, 3},
+	{1, 3, 5, 13},
+	{1, 1, 5, 5, 17},
+	{1, 1, 5, 5, 5},
+	{1, 1, 7, 11, 19},
+	{1, 1, 5, 1, 1},
+	{1, 1, 1, 3, 11},
+	{1, 3, 5, 5, 31},
+	{1, 3, 3, 9, 7, 49},
+	{1, 1, 1, 15, 21, 21},
+	{1, 3, 1, 13, 27, 49},
+	{1, 1, 1, 15, 7, 5},
+	{1, 3, 1, 15, 13, 25},
+	{1, 1, 5, 5, 19, 61},
+	{1, 3, 7, 11, 23, 15, 103},
+	{1, 3, 7, 13, 13, 15, 69},
+}
+
+var sobolCache struct {
+	sync.Mutex
+	dirs  [][32]uint32
+	polys []uint64
+}
+
+// sobolDirections returns the 32-bit direction numbers of the first dim
+// dimensions, generating and caching new dimensions on demand.
+func sobolDirections(dim int) [][32]uint32 {
+	sobolCache.Lock()
+	defer sobolCache.Unlock()
+
+	for len(sobolCache.dirs) < dim {
+		j := len(sobolCache.dirs)
+		var v [32]uint32
+		if j == 0 {
+			// van der Corput sequence in base 2
+			for b := range v {
+				v[b] = 1 << (31 - b)
+			}
+			sobolCache.dirs = append(sobolCache.dirs, v)
+			continue
+		}
+
+		poly := nextPrimitivePolynomial(sobolCache.polys)
+		sobolCache.polys = append(sobolCache.polys, poly)
+		s := bits.Len64(poly) - 1
+
+		m := make([]uint32, s)
+		if j-1 < len(sobolInitial) {
+			copy(m, sobolInitial[j-1])
+		} else {
+			rng := splitMix64(uint64(j))
+			for k := range m {
+				m[k] = uint32(rng.next()%(1<<(k+1))) | 1
+			}
+		}
+
+		for b := 0; b < 32; b++ {
+			if b < s {
+				v[b] = m[b] << (31 - b)
+				continue
+			}
+			v[b] = v[b-s] ^ (v[b-s] >> s)
+			for k := 1; k < s; k++ {
+				if poly>>(s-k)&1 == 1 {
+					v[b] ^= v[b-k]
+				}
+			}
+		}
+		sobolCache.dirs = append(sobolCache.dirs, v)
+	}
+	return sobolCache.dirs[:dim]
+}
+
+// nextPrimitivePolynomial returns the primitive polynomial over GF(2) that
+// follows the last one in polys, ordered by degree and then by value. Bit k
+// of the result is the coefficient of x^k.
+func nextPrimitivePolynomial(polys []uint64) uint64 {
+	p := uint64(2) // x, so the search starts at x+1
+	if len(polys) > 0 {
+		p = polys[len(polys)-1]
+	}
+	for {
+		p++
+		if p&1 == 1 && isPrimitive(p) {
+			return p
+		}
+	}
+}
+
+// isPrimitive reports whether the polynomial p of degree s generates the
+// multiplicative group of GF(2^s), i.e. x has order exactly 2^s-1 mod p.
+func isPrimitive(p uint64) bool {
+	s := bits.Len64(p) - 1
+	order := uint64(1)<<s - 1
+	if polyPowMod(2, order, p) != 1 {
+		return false
+	}
+	for _, q := range primeFactors(order) {
+		if polyPowMod(2, order/q, p) == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// polyPowMod returns a^e mod p over GF(2).
+func polyPowMod(a, e, p uint64) uint64 {
+	result := uint64(1)
+	a = polyMulMod(1, a, p) // reduce a below the degree of p
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = polyMulMod(result, a, p)
+		}
+		a = polyMulMod(a, a, p)
+	}
+	return result
+}
+
+// polyMulMod returns a*b mod p over GF(2).
+func polyMulMod(a, b, p uint64) uint64 {
+	s := bits.Len64(p) - 1
+	var result uint64
+	for ; b > 0; b >>= 1 {
+		if b&1 == 1 {
+			result ^= a
+		}
+		a <<= 1
+		if a>>s&1 == 1 {
+			a ^= p
+		}
+	}
+	return result
+}
+
+// primeFactors returns the distinct prime factors of n.
+func primeFactors(n uint64) []uint64 {
+	var factors []uint64
+	for q := uint64(2); q*q <= n; q++ {
+		if n%q == 0 {
+			factors = append(factors, q)
+			for n%q == 0 {
+				n /= q
+			}
+		}
+	}
+	if n > 1 {
+		factors = append(factors, n)
+	}
+	return factors
+}
@@ -0,0 +1,195 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNoConvergence is returned when an iterative solver fails to reach its
+// tolerance within the iteration budget.
+var ErrNoConvergence = errors.New("pricing: solver did not converge")
+
+// errNotBracketed is returned by brent when f(a) and f(b) share a sign.
+var errNotBracketed = errors.New("pricing: root is not bracketed")
+
+// brent finds a root of f in [a, b] with Brent's method. f(a) and f(b)
+// must have opposite signs.
+func brent(f func(float64) float64, a, b, tol float64, maxIter int) (float64, error) {
+	fa, fb := f(a), f(b)
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	if math.Signbit(fa) == math.Signbit(fb) {
+		return 0, errNotBracketed
+	}
+
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for i := 0; i < maxIter; i++ {
+		if math.Signbit(fb) == math.Signbit(fc) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		tol1 := 2*math.SmallestNonzeroFloat64 + 0.5*tol
+		m := 0.5 * (c - b)
+		if math.Abs(m) <= tol1 || fb == 0 {
+			return b, nil
+		}
+
+		if math.Abs(e) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			// Attempt inverse quadratic interpolation (secant if a == c)
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				qa := fa / fc
+				r := fb / fc
+				p = s * (2*m*qa*(qa-r) - (b-a)*(r-1))
+				q = (qa - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(tol1*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = m
+				e = d
+			}
+		} else {
+			// Bisection
+			d = m
+			e = d
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else {
+			b += math.Copysign(tol1, m)
+		}
+		fb = f(b)
+	}
+	return b, ErrNoConvergence
+}
+
+// levenbergMarquardt minimises the sum of the m squared residuals written by
+// f, starting from x0, with a forward-difference Jacobian. Non-finite
+// residuals reject a step, so f can signal an infeasible x with NaN. It
+// returns the best point found and the number of iterations taken.
+func levenbergMarquardt(ctx context.Context, f func(x, r []float64), m int, x0 []float64, maxIter int) ([]float64, int, error) {
+	n := len(x0)
+	x := append([]float64(nil), x0...)
+	r := make([]float64, m)
+	f(x, r)
+	cost := sumSquares(r)
+	if math.IsInf(cost, 1) {
+		return nil, 0, fmt.Errorf("%w: residuals are not finite at the starting point", ErrInvalidInput)
+	}
+
+	J := make([][]float64, m)
+	for i := range J {
+		J[i] = make([]float64, n)
+	}
+	A := make([][]float64, n)
+	for i := range A {
+		A[i] = make([]float64, n)
+	}
+	g := make([]float64, n)
+	xh, rh := make([]float64, n), make([]float64, m)
+	lambda := 1e-3
+
+	iter := 0
+	for ; iter < maxIter; iter++ {
+		if err := ctx.Err(); err != nil {
+			return nil, iter, err
+		}
+
+		for j := 0; j < n; j++ {
+			copy(xh, x)
+			h := 1e-6 * math.Max(1, math.Abs(x[j]))
+			xh[j] += h
+			f(xh, rh)
+			for i := range J {
+				J[i][j] = (rh[i] - r[i]) / h
+			}
+		}
+		for a := 0; a < n; a++ {
+			g[a] = 0
+			for i := range J {
+				g[a] -= J[i][a] * r[i]
+			}
+			for b := 0; b < n; b++ {
+				A[a][b] = 0
+				for i := range J {
+					A[a][b] += J[i][a] * J[i][b]
+				}
+			}
+		}
+
+		// Raise the damping until a step lowers the cost
+		improved := false
+		for try := 0; try < 12 && !improved; try++ {
+			M := make([][]float64, n)
+			for a := range M {
+				M[a] = append([]float64(nil), A[a]...)
+				M[a][a] += lambda * math.Max(A[a][a], 1e-12)
+			}
+			step, err := solveLinear(M, g)
+			if err != nil {
+				lambda *= 10
+				continue
+			}
+			for j := range xh {
+				xh[j] = x[j] + step[j]
+			}
+			f(xh, rh)
+			if c := sumSquares(rh); c < cost {
+				improved = true
+				x, xh = xh, x
+				r, rh = rh, r
+				gain := cost - c
+				cost = c
+				lambda = math.Max(lambda/10, 1e-12)
+				if gain <= 1e-12*cost+1e-24 {
+					return x, iter + 1, nil
+				}
+			} else {
+				lambda *= 10
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return x, iter, nil
+}
+
+// sumSquares returns the sum of the squares of r; NaN residuals give +Inf.
+func sumSquares(r []float64) float64 {
+	s := 0.0
+	for _, v := range r {
+		s += v * v
+	}
+	if math.IsNaN(s) {
+		return math.Inf(1)
+	}
+	return s
+}
@@ -0,0 +1,414 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+)
+
+// VolQuote is a quoted implied volatility at a strike. Expiry, in years,
+// is read by calibrators spanning several expiries; single-smile
+// calibrators take theirs as an argument.
+type VolQuote struct {
+	Expiry float64
+	Strike float64
+	Vol    float64
+	// Weight scales the quote's volatility error in a calibration; 1 when
+	// zero.
+	Weight float64
+}
+
+// SVIParams are the raw SVI parameters of Gatheral (2004), which give the
+// total implied variance of one expiry at log-moneyness k = ln(K/F) as
+//
+//	w(k) = A + B (Rho (k - M) + sqrt((k - M)^2 + Sigma^2))
+type SVIParams struct {
+	A     float64 // variance level
+	B     float64 // slope of the wings
+	Rho   float64 // skew, between the left and right wing
+	M     float64 // log-moneyness of the smile's minimum
+	Sigma float64 // curvature at the minimum
+}
+
+// Validate checks that the parameters give a positive total variance.
+func (p SVIParams) Validate() error {
+	if err := finite("svi a", p.A); err != nil {
+		return err
+	}
+	if math.IsNaN(p.B) || math.IsInf(p.B, 0) || p.B < 0 {
+		return &InputError{Field: "svi b", Value: p.B}
+	}
+	if math.IsNaN(p.Rho) || p.Rho <= -1 || p.Rho >= 1 {
+		return &InputError{Field: "svi rho", Value: p.Rho}
+	}
+	if err := finite("svi m", p.M); err != nil {
+		return err
+	}
+	if err := positive("svi sigma", p.Sigma); err != nil {
+		return err
+	}
+	if lo := p.minVariance(); lo < 0 {
+		return &InputError{Field: "svi minimum variance", Value: lo}
+	}
+	return nil
+}
+
+// TotalVariance returns the total implied variance at log-moneyness k.
+func (p SVIParams) TotalVariance(k float64) float64 {
+	d := k - p.M
+	return p.A + p.B*(p.Rho*d+math.Sqrt(d*d+p.Sigma*p.Sigma))
+}
+
+// minVariance returns the smallest total variance of the smile.
+func (p SVIParams) minVariance() float64 {
+	return p.A + p.B*p.Sigma*math.Sqrt(1-p.Rho*p.Rho)
+}
+
+// SVISmile is the raw SVI smile of an expiry, Expiry years out, on the
+// forward Forward. As a volatility surface it holds the smile's volatility
+// at each strike for every expiry.
+type SVISmile struct {
+	Params  SVIParams
+	Forward float64
+	Expiry  float64
+}
+
+var _ market.VolSurface = SVISmile{}
+
+// Volatility returns the smile's implied volatility at strike.
+func (s SVISmile) Volatility(_, strike float64) (float64, error) {
+	if err := positive("strike", strike); err != nil {
+		return 0, err
+	}
+	w := s.Params.TotalVariance(math.Log(strike / s.Forward))
+	if err := positive("svi total variance", w); err != nil {
+		return 0, err
+	}
+	return math.Sqrt(w / s.Expiry), nil
+}
+
+// SVICalibration is the outcome of fitting a raw SVI smile to quotes.
+type SVICalibration struct {
+	Smile SVISmile
+	// RMSE is the root mean square of the weighted volatility errors.
+	RMSE       float64
+	Iterations int
+}
+
+// CalibrateSVI fits a raw SVI smile to the implied volatilities of options
+// on forward expiring in expiry years, by Levenberg-Marquardt least
+// squares. The fitted smile's variance stays positive at every strike.
+func CalibrateSVI(ctx context.Context, forward, expiry float64, quotes []VolQuote) (SVICalibration, error) {
+	if len(quotes) < 5 {
+		return SVICalibration{}, fmt.Errorf("%w: %d quotes for 5 SVI parameters", ErrInvalidInput, len(quotes))
+	}
+	if err := positive("forward", forward); err != nil {
+		return SVICalibration{}, err
+	}
+	if err := positive("expiry", expiry); err != nil {
+		return SVICalibration{}, err
+	}
+	if err := validateVolQuotes(quotes); err != nil {
+		return SVICalibration{}, err
+	}
+
+	k := make([]float64, len(quotes))
+	atm := math.Inf(1)
+	var wATM float64
+	for i, q := range quotes {
+		k[i] = math.Log(q.Strike / forward)
+		if math.Abs(k[i]) < atm {
+			atm, wATM = math.Abs(k[i]), q.Vol*q.Vol*expiry
+		}
+	}
+
+	residuals := func(x, r []float64) {
+		p := sviFromUnconstrained(x)
+		for i, q := range quotes {
+			r[i] = q.weight() * (math.Sqrt(p.TotalVariance(k[i])/expiry) - q.Vol)
+		}
+	}
+	// Start from a smile through the at-the-money variance, with the wings
+	// scaled to it so that the minimum variance stays positive for short
+	// or quiet expiries, and keep the best of a few skews
+	var best SVICalibration
+	var lastErr error
+	for _, rho := range []float64{-0.5, 0, 0.5} {
+		start := SVIParams{B: math.Min(0.1, wATM/0.2), Rho: rho, Sigma: 0.1}
+		start.A = wATM - start.B*start.Sigma
+		x, iters, err := levenbergMarquardt(ctx, residuals, len(quotes), sviToUnconstrained(start), 200)
+		if err != nil {
+			if ctx.Err() != nil {
+				return SVICalibration{}, err
+			}
+			lastErr = err
+			continue
+		}
+		r := make([]float64, len(quotes))
+		residuals(x, r)
+		rmse := math.Sqrt(sumSquares(r) / float64(len(r)))
+		if best.Smile.Expiry == 0 || rmse < best.RMSE {
+			best = SVICalibration{
+				Smile:      SVISmile{Params: sviFromUnconstrained(x), Forward: forward, Expiry: expiry},
+				RMSE:       rmse,
+				Iterations: iters,
+			}
+		}
+	}
+	if best.Smile.Expiry == 0 {
+		return SVICalibration{}, lastErr
+	}
+	return best, nil
+}
+
+// sviToUnconstrained maps raw SVI parameters to R^5, replacing A by the log
+// of the smile's minimum variance, so that the variance stays positive.
+func sviToUnconstrained(p SVIParams) []float64 {
+	return []float64{math.Log(p.minVariance()), math.Log(p.B), math.Atanh(p.Rho), p.M, math.Log(p.Sigma)}
+}
+
+func sviFromUnconstrained(x []float64) SVIParams {
+	p := SVIParams{B: math.Exp(x[1]), Rho: math.Tanh(x[2]), M: x[3], Sigma: math.Exp(x[4])}
+	p.A = math.Exp(x[0]) - p.B*p.Sigma*math.Sqrt(1-p.Rho*p.Rho)
+	return p
+}
+
+// SSVIParams are the parameters of the surface SVI of Gatheral and
+// Jacquier (2014) with a power-law curvature. At-the-money total variance
+// theta gives the total variance
+//
+//	w(k, theta) = theta/2 (1 + Rho phi k + sqrt((phi k + Rho)^2 + 1 - Rho^2))
+//	phi(theta)  = Eta / (theta^Gamma (1 + theta)^(1 - Gamma))
+//
+// The surface is free of butterfly arbitrage when Eta (1 + |Rho|) <= 2 and
+// Gamma is at most 1/2, and of calendar arbitrage when theta does not fall
+// with expiry.
+type SSVIParams struct {
+	Rho   float64
+	Eta   float64
+	Gamma float64
+}
+
+// Validate checks that the parameters define an SSVI surface.
+func (p SSVIParams) Validate() error {
+	if math.IsNaN(p.Rho) || p.Rho <= -1 || p.Rho >= 1 {
+		return &InputError{Field: "ssvi rho", Value: p.Rho}
+	}
+	if err := positive("ssvi eta", p.Eta); err != nil {
+		return err
+	}
+	if math.IsNaN(p.Gamma) || p.Gamma <= 0 || p.Gamma > 1 {
+		return &InputError{Field: "ssvi gamma", Value: p.Gamma}
+	}
+	return nil
+}
+
+// ArbitrageFree reports whether the parameters meet the sufficient
+// condition for the surface to be free of butterfly arbitrage.
+func (p SSVIParams) ArbitrageFree() bool {
+	return p.Eta*(1+math.Abs(p.Rho)) <= 2 && p.Gamma <= 0.5
+}
+
+// TotalVariance returns the total implied variance at log-moneyness k on
+// the slice whose at-the-money total variance is theta.
+func (p SSVIParams) TotalVariance(theta, k float64) float64 {
+	phi := p.Eta / (math.Pow(theta, p.Gamma) * math.Pow(1+theta, 1-p.Gamma))
+	a := phi*k + p.Rho
+	return theta / 2 * (1 + p.Rho*phi*k + math.Sqrt(a*a+1-p.Rho*p.Rho))
+}
+
+// SSVISurface is an SSVI volatility surface of an underlying at Spot. The
+// at-the-money total variance ATMVariance[i] at Expiries[i], in increasing
+// years, is interpolated linearly in time, and extrapolated at the
+// volatility of the last expiry.
+type SSVISurface struct {
+	Params        SSVIParams
+	Spot          float64
+	RiskFreeRate  float64
+	DividendYield float64
+	Expiries      []float64
+	ATMVariance   []float64
+}
+
+var _ market.VolSurface = (*SSVISurface)(nil)
+
+// Volatility returns the implied volatility at expiry and strike.
+func (s *SSVISurface) Volatility(expiry, strike float64) (float64, error) {
+	if err := positive("expiry", expiry); err != nil {
+		return 0, err
+	}
+	if err := positive("strike", strike); err != nil {
+		return 0, err
+	}
+	if len(s.Expiries) == 0 || len(s.ATMVariance) != len(s.Expiries) {
+		return 0, fmt.Errorf("%w: SSVI surface has %d expiries and %d variances", ErrInvalidInput, len(s.Expiries), len(s.ATMVariance))
+	}
+	theta := s.atmVariance(expiry)
+	k := math.Log(strike/s.Spot) - (s.RiskFreeRate-s.DividendYield)*expiry
+	w := s.Params.TotalVariance(theta, k)
+	if err := positive("ssvi total variance", w); err != nil {
+		return 0, err
+	}
+	return math.Sqrt(w / expiry), nil
+}
+
+// atmVariance interpolates the at-the-money total variance at t years.
+func (s *SSVISurface) atmVariance(t float64) float64 {
+	n := len(s.Expiries)
+	i := sort.SearchFloat64s(s.Expiries, t)
+	if i == n {
+		return s.ATMVariance[n-1] * t / s.Expiries[n-1]
+	}
+	t0, w0 := 0.0, 0.0
+	if i > 0 {
+		t0, w0 = s.Expiries[i-1], s.ATMVariance[i-1]
+	}
+	return w0 + (s.ATMVariance[i]-w0)*(t-t0)/(s.Expiries[i]-t0)
+}
+
+// SSVICalibration is the outcome of fitting an SSVI surface to quotes.
+type SSVICalibration struct {
+	Surface *SSVISurface
+	// RMSE is the root mean square of the weighted volatility errors.
+	RMSE       float64
+	Iterations int
+}
+
+// CalibrateSSVI fits an SSVI surface to implied volatilities quoted at
+// several expiries, on an underlying at spot with rate r and dividend
+// yield q, by Levenberg-Marquardt least squares. Along with Rho, Eta and
+// Gamma it fits the at-the-money total variance of every quoted expiry.
+// The fit is kept inside the no-arbitrage conditions of SSVIParams.
+func CalibrateSSVI(ctx context.Context, spot, r, q float64, quotes []VolQuote) (SSVICalibration, error) {
+	if err := positive("spot", spot); err != nil {
+		return SSVICalibration{}, err
+	}
+	if err := finite("rate", r); err != nil {
+		return SSVICalibration{}, err
+	}
+	if err := finite("dividend yield", q); err != nil {
+		return SSVICalibration{}, err
+	}
+	if err := validateVolQuotes(quotes); err != nil {
+		return SSVICalibration{}, err
+	}
+	for _, quote := range quotes {
+		if err := positive("expiry", quote.Expiry); err != nil {
+			return SSVICalibration{}, err
+		}
+	}
+
+	surface := &SSVISurface{Spot: spot, RiskFreeRate: r, DividendYield: q}
+	for _, quote := range quotes {
+		surface.Expiries = append(surface.Expiries, quote.Expiry)
+	}
+	slices.Sort(surface.Expiries)
+	surface.Expiries = slices.Compact(surface.Expiries)
+	n := len(surface.Expiries)
+	if len(quotes) < n+3 {
+		return SSVICalibration{}, fmt.Errorf("%w: %d quotes for %d SSVI parameters", ErrInvalidInput, len(quotes), n+3)
+	}
+
+	// The quote nearest the money at each expiry starts its variance
+	k := make([]float64, len(quotes))
+	slice := make([]int, len(quotes))
+	nearest := make([]float64, n)
+	theta := make([]float64, n)
+	for i := range nearest {
+		nearest[i] = math.Inf(1)
+	}
+	for i, quote := range quotes {
+		slice[i] = sort.SearchFloat64s(surface.Expiries, quote.Expiry)
+		k[i] = math.Log(quote.Strike/spot) - (r-q)*quote.Expiry
+		if j := slice[i]; math.Abs(k[i]) < nearest[j] {
+			nearest[j], theta[j] = math.Abs(k[i]), quote.Vol*quote.Vol*quote.Expiry
+		}
+	}
+	for j := 1; j < n; j++ {
+		theta[j] = math.Max(theta[j], theta[j-1]*(1+1e-6))
+	}
+
+	residuals := func(x, res []float64) {
+		p, thetas := ssviFromUnconstrained(x)
+		for i, quote := range quotes {
+			w := p.TotalVariance(thetas[slice[i]], k[i])
+			res[i] = quote.weight() * (math.Sqrt(w/quote.Expiry) - quote.Vol)
+		}
+	}
+	start := SSVIParams{Rho: -0.3, Eta: 1, Gamma: 0.25}
+	x, iters, err := levenbergMarquardt(ctx, residuals, len(quotes), ssviToUnconstrained(start, theta), 200)
+	if err != nil {
+		return SSVICalibration{}, err
+	}
+
+	res := make([]float64, len(quotes))
+	residuals(x, res)
+	surface.Params, surface.ATMVariance = ssviFromUnconstrained(x)
+	return SSVICalibration{
+		Surface:    surface,
+		RMSE:       math.Sqrt(sumSquares(res) / float64(len(res))),
+		Iterations: iters,
+	}, nil
+}
+
+// ssviToUnconstrained maps SSVI parameters and at-the-money variances to
+// unconstrained coordinates: Eta as a fraction of its bound 2/(1+|Rho|),
+// Gamma as a fraction of 1/2, and the variances as logs of their
+// increments, so that the optimiser stays free of arbitrage.
+func ssviToUnconstrained(p SSVIParams, theta []float64) []float64 {
+	x := []float64{math.Atanh(p.Rho), logit(p.Eta * (1 + math.Abs(p.Rho)) / 2), logit(2 * p.Gamma)}
+	prev := 0.0
+	for _, t := range theta {
+		x = append(x, math.Log(t-prev))
+		prev = t
+	}
+	return x
+}
+
+func ssviFromUnconstrained(x []float64) (SSVIParams, []float64) {
+	p := SSVIParams{Rho: math.Tanh(x[0])}
+	p.Eta = 2 / (1 + math.Abs(p.Rho)) * logistic(x[1])
+	p.Gamma = logistic(x[2]) / 2
+	theta := make([]float64, len(x)-3)
+	prev := 0.0
+	for i, v := range x[3:] {
+		prev += math.Exp(v)
+		theta[i] = prev
+	}
+	return p, theta
+}
+
+func logit(p float64) float64 {
+	return math.Log(p / (1 - p))
+}
+
+func logistic(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+// validateVolQuotes checks quoted strikes and volatilities are positive.
+func validateVolQuotes(quotes []VolQuote) error {
+	for _, q := range quotes {
+		if err := positive("strike", q.Strike); err != nil {
+			return err
+		}
+		if err := positive("quoted volatility", q.Vol); err != nil {
+			return err
+		}
+		if err := finite("weight", q.Weight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q VolQuote) weight() float64 {
+	if q.Weight == 0 {
+		return 1
+	}
+	return q.Weight
+}
@@ -0,0 +1,141 @@
+package pricing
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSVIParams_Validate(t *testing.T) {
+	good := SVIParams{A: 0.02, B: 0.1, Rho: -0.4, M: 0.05, Sigma: 0.2}
+	assert.NoError(t, good.Validate())
+	// The minimum of the smile is at M - Rho Sigma / sqrt(1 - Rho^2)
+	kMin := good.M - good.Rho*good.Sigma/math.Sqrt(1-good.Rho*good.Rho)
+	assert.InDelta(t, good.minVariance(), good.TotalVariance(kMin), 1e-12)
+
+	for name, p := range map[string]SVIParams{
+		"negative b":       {A: 0.02, B: -0.1, Sigma: 0.2},
+		"rho of one":       {A: 0.02, B: 0.1, Rho: 1, Sigma: 0.2},
+		"zero sigma":       {A: 0.02, B: 0.1},
+		"negative minimum": {A: -0.05, B: 0.1, Sigma: 0.2},
+	} {
+		assert.ErrorIs(t, p.Validate(), ErrInvalidInput, name)
+	}
+}
+
+func TestCalibrateSVI_RecoversSmile(t *testing.T) {
+	want := SVIParams{A: 0.02, B: 0.12, Rho: -0.5, M: 0.03, Sigma: 0.15}
+	const forward, expiry = 100.0, 0.75
+	var quotes []VolQuote
+	for K := 60.0; K <= 150; K += 10 {
+		vol, err := SVISmile{Params: want, Forward: forward, Expiry: expiry}.Volatility(expiry, K)
+		assert.NoError(t, err)
+		quotes = append(quotes, VolQuote{Strike: K, Vol: vol})
+	}
+
+	cal, err := CalibrateSVI(context.Background(), forward, expiry, quotes)
+	assert.NoError(t, err)
+	assert.Less(t, cal.RMSE, 1e-6)
+	assert.NoError(t, cal.Smile.Params.Validate())
+	for _, q := range quotes {
+		vol, err := cal.Smile.Volatility(expiry, q.Strike)
+		assert.NoError(t, err)
+		assert.InDelta(t, q.Vol, vol, 1e-5)
+	}
+	assert.InDelta(t, want.Rho, cal.Smile.Params.Rho, 0.01)
+
+	_, err = CalibrateSVI(context.Background(), forward, expiry, quotes[:4])
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestCalibrateSVI_ShortDatedLowVol(t *testing.T) {
+	// At 10% volatility five weeks out the at-the-money total variance is
+	// about 0.001, far below the default wings
+	want := SVIParams{A: 0.0006, B: 0.01, Rho: -0.3, M: 0.01, Sigma: 0.03}
+	const forward, expiry = 100.0, 0.1
+	var quotes []VolQuote
+	for K := 90.0; K <= 110; K += 2.5 {
+		vol, err := SVISmile{Params: want, Forward: forward, Expiry: expiry}.Volatility(expiry, K)
+		assert.NoError(t, err)
+		quotes = append(quotes, VolQuote{Strike: K, Vol: vol})
+	}
+
+	cal, err := CalibrateSVI(context.Background(), forward, expiry, quotes)
+	assert.NoError(t, err)
+	assert.Less(t, cal.RMSE, 1e-4)
+	for _, q := range quotes {
+		vol, err := cal.Smile.Volatility(expiry, q.Strike)
+		assert.NoError(t, err)
+		assert.InDelta(t, q.Vol, vol, 1e-3)
+	}
+}
+
+func TestCalibrateSSVI_RecoversSurface(t *testing.T) {
+	want := &SSVISurface{
+		Params:        SSVIParams{Rho: -0.6, Eta: 1.1, Gamma: 0.4},
+		Spot:          100,
+		RiskFreeRate:  0.03,
+		DividendYield: 0.01,
+		Expiries:      []float64{0.25, 0.5, 1, 2},
+		ATMVariance:   []float64{0.012, 0.022, 0.042, 0.08},
+	}
+	assert.True(t, want.Params.ArbitrageFree())
+	var quotes []VolQuote
+	for _, T := range want.Expiries {
+		for K := 70.0; K <= 130; K += 10 {
+			vol, err := want.Volatility(T, K)
+			assert.NoError(t, err)
+			quotes = append(quotes, VolQuote{Expiry: T, Strike: K, Vol: vol})
+		}
+	}
+
+	cal, err := CalibrateSSVI(context.Background(), 100, 0.03, 0.01, quotes)
+	assert.NoError(t, err)
+	assert.Less(t, cal.RMSE, 1e-6)
+	assert.True(t, cal.Surface.Params.ArbitrageFree())
+	assert.Equal(t, want.Expiries, cal.Surface.Expiries)
+	assert.InDelta(t, want.Params.Rho, cal.Surface.Params.Rho, 1e-3)
+	assert.InDelta(t, want.Params.Eta, cal.Surface.Params.Eta, 1e-3)
+	assert.InDelta(t, want.Params.Gamma, cal.Surface.Params.Gamma, 1e-3)
+	for i, theta := range want.ATMVariance {
+		assert.InDelta(t, theta, cal.Surface.ATMVariance[i], 1e-6)
+	}
+
+	// Between expiries the at-the-money variance is interpolated in time,
+	// and beyond them the last volatility is held
+	atm := func(T float64) float64 { return 100 * math.Exp(0.02*T) }
+	vol, err := cal.Surface.Volatility(0.75, atm(0.75))
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Sqrt(0.032/0.75), vol, 1e-5)
+	vol, err = cal.Surface.Volatility(4, atm(4))
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Sqrt(0.08/2), vol, 1e-5)
+}
+
+func TestSVISmile_PricesAsVolSurface(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	smile := SVISmile{Params: SVIParams{A: 0.03, B: 0.1, Rho: -0.3, Sigma: 0.2}, Forward: 100 * math.Exp(0.05), Expiry: 1}
+	mkt := market.NewSnapshot(now).SetSpot("TEST", 100).SetVolSurface("TEST", smile)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+
+	// The negative skew gives lower strikes higher volatilities
+	bs := NewBlackScholesPricer(mkt, 0.05, 0.2)
+	var vols []float64
+	for _, K := range []float64{80, 100, 120} {
+		opt := instrument.NewEuropeanOption("P", underlying, decimal.NewFromFloat(K), yearsFrom(now, 1), instrument.Put)
+		res, err := bs.Price(context.Background(), opt)
+		assert.NoError(t, err)
+		want, _ := smile.Volatility(1, K)
+		assert.InDelta(t, want, res.Inputs.Volatility, 1e-12)
+		assert.InDelta(t, bsPrice(res.Inputs, instrument.Put), res.Value(), 1e-9)
+		vols = append(vols, res.Inputs.Volatility)
+	}
+	assert.Greater(t, vols[0], vols[1])
+	assert.Greater(t, vols[1], vols[2])
+}
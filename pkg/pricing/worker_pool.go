@@ -0,0 +1,138 @@
+package pricing
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// DefaultBatchSize is the number of paths simulated per batch when
+// MonteCarloPricer.BatchSize is zero.
+const DefaultBatchSize = 1024
+
+// WorkerPool is a fixed set of long-lived goroutines that run simulation
+// batches. A pool can be shared by any number of pricers and concurrent
+// pricing calls, which avoids spawning goroutines for every price and bounds
+// the total parallelism of a process.
+type WorkerPool struct {
+	tasks   chan func()
+	workers int
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// NewWorkerPool starts a pool of workers goroutines, or GOMAXPROCS when
+// workers is not positive. Close must be called to release them.
+func NewWorkerPool(workers int) *WorkerPool {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	p := &WorkerPool{tasks: make(chan func()), workers: workers}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.wg.Done()
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+	return p
+}
+
+// Workers returns the number of goroutines in the pool.
+func (p *WorkerPool) Workers() int {
+	return p.workers
+}
+
+// Close stops the workers once queued batches have finished. The pool must
+// not be used after Close.
+func (p *WorkerPool) Close() {
+	p.once.Do(func() {
+		close(p.tasks)
+		p.wg.Wait()
+	})
+}
+
+// run calls fn for every batch index in [0, batches) on the pool's workers
+// and waits for them to finish. Batches not yet started when ctx is
+// cancelled are skipped.
+func (p *WorkerPool) run(ctx context.Context, batches int, fn func(batch int)) error {
+	var wg sync.WaitGroup
+	for b := 0; b < batches; b++ {
+		b := b
+		wg.Add(1)
+		task := func() {
+			defer wg.Done()
+			if ctx.Err() == nil {
+				fn(b)
+			}
+		}
+		select {
+		case p.tasks <- task:
+		case <-ctx.Done():
+			wg.Done()
+			wg.Wait()
+			return ctx.Err()
+		}
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// batchPlan splits the paths of a run into fixed-size batches. Batch
+// boundaries depend only on the path count and batch size, never on the
+// number of workers, so a seed gives the same result on any machine.
+type batchPlan struct {
+	paths int
+	size  int
+}
+
+func (b batchPlan) count() int {
+	return (b.paths + b.size - 1) / b.size
+}
+
+// bounds returns the first path of batch i and the number of paths in it.
+func (b batchPlan) bounds(i int) (int, int) {
+	first := i * b.size
+	return first, min(b.size, b.paths-first)
+}
+
+// plan returns the batches of one run after validating the run settings.
+func (mc *MonteCarloPricer) plan() (batchPlan, error) {
+	if mc.Simulations < 1 {
+		return batchPlan{}, &InputError{Field: "simulations", Value: float64(mc.Simulations)}
+	}
+	if mc.BatchSize < 0 {
+		return batchPlan{}, &InputError{Field: "batch size", Value: float64(mc.BatchSize)}
+	}
+	if mc.Tolerance < 0 {
+		return batchPlan{}, &InputError{Field: "tolerance", Value: mc.Tolerance}
+	}
+	if mc.Confidence < 0 || mc.Confidence >= 1 {
+		return batchPlan{}, &InputError{Field: "confidence", Value: mc.Confidence}
+	}
+	if mc.TimeBudget < 0 {
+		return batchPlan{}, &InputError{Field: "time budget", Value: mc.TimeBudget.Seconds()}
+	}
+	size := mc.BatchSize
+	if size == 0 {
+		size = DefaultBatchSize
+	}
+	return batchPlan{paths: mc.Simulations, size: size}, nil
+}
+
+// runBatches runs fn for every batch on the shared Pool, or on a pool of
+// Workers goroutines created for this call.
+func (mc *MonteCarloPricer) runBatches(ctx context.Context, batches int, fn func(batch int)) error {
+	pool := mc.Pool
+	if pool == nil {
+		workers := mc.Workers
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		pool = NewWorkerPool(min(workers, batches))
+		defer pool.Close()
+	}
+	return pool.run(ctx, batches, fn)
+}
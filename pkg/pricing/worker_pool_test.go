@@ -0,0 +1,117 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/shopspring/decimal"
+)
+
+func TestMonteCarloPricer_SimulatesExactPathCount(t *testing.T) {
+	for _, sims := range []int{1, 999, 1001, 4097} {
+		mc := NewMonteCarloPricer(nil, sims, 0, 0)
+		mc.BatchSize = 100
+		mc.Workers = 3
+		stats, _, err := mc.simulate(context.Background(), 1, simSpec{
+			dim:    1,
+			width:  1,
+			sample: func(_ []float64, out []float64) { out[0] = 1 },
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if int(stats[0].sum) != sims {
+			t.Errorf("sims=%d: simulated %v paths", sims, stats[0].sum)
+		}
+	}
+
+	mc := NewMonteCarloPricer(nil, 0, 0, 0)
+	if _, err := mc.plan(); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for zero simulations, got %v", err)
+	}
+}
+
+func TestMonteCarloPricer_ResultIndependentOfWorkers(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	expiry := now.Add(365 * 24 * time.Hour)
+	european := instrument.NewEuropeanOption("OPT1", underlying, decimal.NewFromInt(100), expiry, instrument.Call)
+	american := instrument.NewAmericanOption("OPT2", underlying, decimal.NewFromInt(100), expiry, instrument.Put)
+
+	pool := NewWorkerPool(4)
+	defer pool.Close()
+
+	for _, inst := range []instrument.Instrument{european, american} {
+		var want PricingResult
+		for i, workers := range []int{1, 3, 16, -1} {
+			pricer := NewMonteCarloPricer(mkt, 10007, 0.05, 0.20)
+			pricer.Seed = 42
+			pricer.Workers = workers
+			if workers < 0 {
+				pricer.Pool = pool
+			}
+			res, err := pricer.Price(context.Background(), inst)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if i == 0 {
+				want = res
+				continue
+			}
+			if res.Value() != want.Value() || res.StdErr != want.StdErr {
+				t.Errorf("%s: workers=%d gave %v, want %v", inst.ID(), workers, res.Price, want.Price)
+			}
+		}
+	}
+}
+
+func TestWorkerPool_SharedAcrossConcurrentCalls(t *testing.T) {
+	now := time.Now()
+	mkt := market.NewSnapshot(now).SetSpot("AAPL", 100)
+	underlying := instrument.NewEquity("AAPL", "USD", "AAPL")
+	opt := instrument.NewEuropeanOption("OPT1", underlying, decimal.NewFromInt(100), now.Add(365*24*time.Hour), instrument.Call)
+
+	pool := NewWorkerPool(2)
+	defer pool.Close()
+	if pool.Workers() != 2 {
+		t.Fatalf("Expected 2 workers, got %d", pool.Workers())
+	}
+
+	var wg sync.WaitGroup
+	prices := make([]float64, 8)
+	for i := range prices {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pricer := NewMonteCarloPricer(mkt, 20000, 0.05, 0.20)
+			pricer.Seed = 7
+			pricer.Pool = pool
+			res, err := pricer.Price(context.Background(), opt)
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+				return
+			}
+			prices[i] = res.Value()
+		}(i)
+	}
+	wg.Wait()
+	for _, p := range prices[1:] {
+		if p != prices[0] {
+			t.Errorf("shared pool gave %v and %v for the same seed", prices[0], p)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pricer := NewMonteCarloPricer(mkt, 20000, 0.05, 0.20)
+	pricer.Pool = pool
+	if _, err := pricer.Price(ctx, opt); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
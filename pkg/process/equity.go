@@ -0,0 +1,167 @@
+package process
+
+import "math"
+
+// GBM is geometric Brownian motion dS = Drift*S dt + Vol*S dW.
+type GBM struct {
+	S0    float64
+	Drift float64
+	Vol   float64
+}
+
+func (p GBM) Initial() []float64 {
+	return []float64{p.S0}
+}
+
+func (p GBM) Factors() int {
+	return 1
+}
+
+func (p GBM) Supports(scheme Scheme) bool {
+	return scheme == Euler || scheme == Milstein || scheme == Exact
+}
+
+func (p GBM) Step(scheme Scheme, _, dt float64, x, z []float64) {
+	x[0] = gbmStep(scheme, x[0], p.Drift, p.Vol, dt, z[0])
+}
+
+// gbmStep advances a geometric Brownian motion over dt for the normal z.
+func gbmStep(scheme Scheme, s, mu, sigma, dt, z float64) float64 {
+	dW := math.Sqrt(dt) * z
+	switch scheme {
+	case Exact:
+		return s * math.Exp((mu-0.5*sigma*sigma)*dt+sigma*dW)
+	case Milstein:
+		return s * (1 + mu*dt + sigma*dW + 0.5*sigma*sigma*(dW*dW-dt))
+	default:
+		return s * (1 + mu*dt + sigma*dW)
+	}
+}
+
+// Heston is the stochastic volatility model
+//
+//	dS = Drift*S dt + sqrt(v) S dW1
+//	dv = Kappa(Theta - v) dt + Xi sqrt(v) dW2,  dW1 dW2 = Rho dt
+//
+// Its state is (S, v). The log-spot is stepped with the variance frozen
+// over the step and the variance uses full truncation (Lord et al., 2010),
+// so v may dip below zero between steps but never enters a square root.
+type Heston struct {
+	S0    float64
+	V0    float64
+	Drift float64
+	Kappa float64
+	Theta float64
+	Xi    float64
+	Rho   float64
+}
+
+func (p Heston) Initial() []float64 {
+	return []float64{p.S0, p.V0}
+}
+
+func (p Heston) Factors() int {
+	return 2
+}
+
+func (p Heston) Supports(scheme Scheme) bool {
+	return scheme == Euler || scheme == Milstein
+}
+
+func (p Heston) Step(scheme Scheme, _, dt float64, x, z []float64) {
+	v := math.Max(x[1], 0)
+	sqrtDt := math.Sqrt(dt)
+	dW1 := sqrtDt * z[0]
+	dW2 := sqrtDt * (p.Rho*z[0] + math.Sqrt(1-p.Rho*p.Rho)*z[1])
+
+	x[0] *= math.Exp((p.Drift-0.5*v)*dt + math.Sqrt(v)*dW1)
+	next := x[1] + p.Kappa*(p.Theta-v)*dt + p.Xi*math.Sqrt(v)*dW2
+	if scheme == Milstein {
+		next += 0.25 * p.Xi * p.Xi * (dW2*dW2 - dt)
+	}
+	x[1] = next
+}
+
+// Merton is the jump-diffusion
+//
+//	dS/S = (Drift - Lambda*k) dt + Vol dW + (J - 1) dN
+//
+// where N is a Poisson process of intensity Lambda and log J is normal with
+// mean JumpMean and standard deviation JumpVol; k = E[J-1] compensates the
+// drift. Each step draws the diffusion, the jump count (by inversion) and
+// the total jump size from three normals. Jumps are always sampled exactly.
+type Merton struct {
+	S0       float64
+	Drift    float64
+	Vol      float64
+	Lambda   float64
+	JumpMean float64
+	JumpVol  float64
+}
+
+func (p Merton) Initial() []float64 {
+	return []float64{p.S0}
+}
+
+func (p Merton) Factors() int {
+	return 3
+}
+
+func (p Merton) Supports(scheme Scheme) bool {
+	return scheme == Euler || scheme == Milstein || scheme == Exact
+}
+
+func (p Merton) Step(scheme Scheme, _, dt float64, x, z []float64) {
+	k := math.Exp(p.JumpMean+0.5*p.JumpVol*p.JumpVol) - 1
+	s := gbmStep(scheme, x[0], p.Drift-p.Lambda*k, p.Vol, dt, z[0])
+	if n := poissonInv(p.Lambda*dt, normCdf(z[1])); n > 0 {
+		jumps := float64(n)
+		s *= math.Exp(jumps*p.JumpMean + math.Sqrt(jumps)*p.JumpVol*z[2])
+	}
+	x[0] = s
+}
+
+// LocalVolFunc returns the instantaneous volatility at time t and spot s.
+type LocalVolFunc func(t, s float64) float64
+
+// LocalVol is the local volatility model dS = Drift*S dt + Vol(t,S)*S dW.
+// Euler steps the log-spot with the volatility frozen over the step, which
+// keeps the spot positive; Milstein works on the spot and differentiates
+// Vol numerically, and a spot it takes to zero stays there.
+type LocalVol struct {
+	S0    float64
+	Drift float64
+	Vol   LocalVolFunc
+}
+
+func (p LocalVol) Initial() []float64 {
+	return []float64{p.S0}
+}
+
+func (p LocalVol) Factors() int {
+	return 1
+}
+
+func (p LocalVol) Supports(scheme Scheme) bool {
+	return scheme == Euler || scheme == Milstein
+}
+
+func (p LocalVol) Step(scheme Scheme, t, dt float64, x, z []float64) {
+	s := x[0]
+	if s <= 0 {
+		// Zero is absorbing, and Vol need not be defined there
+		return
+	}
+	sigma := p.Vol(t, s)
+	if scheme != Milstein {
+		x[0] = gbmStep(Exact, s, p.Drift, sigma, dt, z[0])
+		return
+	}
+
+	// Milstein on dS = mu S dt + b(S) dW with b(S) = Vol(t,S) S
+	h := math.Max(1e-4*s, 1e-12)
+	bPrime := (p.Vol(t, s+h)*(s+h) - p.Vol(t, s-h)*(s-h)) / (2 * h)
+	dW := math.Sqrt(dt) * z[0]
+	b := sigma * s
+	x[0] = math.Max(s+p.Drift*s*dt+b*dW+0.5*b*bPrime*(dW*dW-dt), 0)
+}
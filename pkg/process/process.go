@@ -0,0 +1,92 @@
+// Package process provides stochastic processes that can be stepped through
+// time to simulate Monte Carlo paths.
+//
+// Every process is driven by standard normal draws only, so that any source
+// of normals (pseudo-random, Sobol, antithetic pairs) can drive it. Jumps
+// and other non-Gaussian shocks are obtained from normals by inversion.
+package process
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Scheme selects how a process is discretised over one time step.
+type Scheme string
+
+const (
+	// Euler is the Euler-Maruyama scheme, strong order 1/2.
+	Euler Scheme = "euler"
+	// Milstein adds the Itô correction term, strong order 1.
+	Milstein Scheme = "milstein"
+	// Exact samples the transition distribution without discretisation
+	// error, for processes where it is known in closed form.
+	Exact Scheme = "exact"
+)
+
+// ErrUnsupportedScheme is returned when a process cannot be stepped with the
+// requested scheme.
+var ErrUnsupportedScheme = errors.New("process: unsupported scheme")
+
+// Process is a Markov process whose state can be advanced step by step.
+type Process interface {
+	// Initial returns the state at time zero. Element 0 is the observed
+	// quantity: the asset price, or the short rate for rate models.
+	Initial() []float64
+	// Factors returns the number of standard normals consumed per step.
+	Factors() int
+	// Supports reports whether Step implements scheme.
+	Supports(scheme Scheme) bool
+	// Step advances the state x in place from t to t+dt using the
+	// standard normals z, len(z) == Factors().
+	Step(scheme Scheme, t, dt float64, x, z []float64)
+}
+
+// Resolve returns scheme, or the exact scheme of p when supported and Euler
+// otherwise if scheme is empty. It fails if p does not support the result.
+func Resolve(p Process, scheme Scheme) (Scheme, error) {
+	if scheme == "" {
+		if p.Supports(Exact) {
+			return Exact, nil
+		}
+		scheme = Euler
+	}
+	if !p.Supports(scheme) {
+		return "", fmt.Errorf("%w: %s for %T", ErrUnsupportedScheme, scheme, p)
+	}
+	return scheme, nil
+}
+
+// Observe simulates one path of p through times, which must be increasing
+// and positive, consuming Factors() normals from z per step. It writes
+// element 0 of the state at each time into out and leaves the final state
+// in x, which must have the length of p.Initial().
+func Observe(p Process, scheme Scheme, times, z, x, out []float64) {
+	copy(x, p.Initial())
+	f := p.Factors()
+	prev := 0.0
+	for k, t := range times {
+		p.Step(scheme, prev, t-prev, x, z[k*f:(k+1)*f])
+		out[k] = x[0]
+		prev = t
+	}
+}
+
+// normCdf is the standard normal cumulative distribution.
+func normCdf(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// poissonInv returns the smallest n with P(N <= n) >= u for N ~ Poisson(mean).
+func poissonInv(mean, u float64) int {
+	p := math.Exp(-mean)
+	cum := p
+	n := 0
+	for u > cum && n < 1000 {
+		n++
+		p *= mean / float64(n)
+		cum += p
+	}
+	return n
+}
@@ -0,0 +1,146 @@
+package process
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// moments simulates n paths of p to T in steps equal steps and returns the
+// mean and variance of state element k at T.
+func moments(p Process, scheme Scheme, T float64, steps, n, k int) (float64, float64) {
+	rng := rand.New(rand.NewSource(1))
+	times := make([]float64, steps)
+	for i := range times {
+		times[i] = T * float64(i+1) / float64(steps)
+	}
+	z := make([]float64, steps*p.Factors())
+	x := make([]float64, len(p.Initial()))
+	out := make([]float64, steps)
+
+	var sum, sumSq float64
+	for i := 0; i < n; i++ {
+		for j := range z {
+			z[j] = rng.NormFloat64()
+		}
+		Observe(p, scheme, times, z, x, out)
+		sum += x[k]
+		sumSq += x[k] * x[k]
+	}
+	mean := sum / float64(n)
+	return mean, sumSq/float64(n) - mean*mean
+}
+
+func TestAssetProcessesAreMartingalesAfterDiscounting(t *testing.T) {
+	const T, mu = 1.0, 0.05
+	forward := 100 * math.Exp(mu*T)
+	tests := []struct {
+		name    string
+		p       Process
+		schemes []Scheme
+	}{
+		{"gbm", GBM{S0: 100, Drift: mu, Vol: 0.2}, []Scheme{Euler, Milstein, Exact}},
+		{"heston", Heston{S0: 100, V0: 0.04, Drift: mu, Kappa: 1.5, Theta: 0.06, Xi: 0.5, Rho: -0.7}, []Scheme{Euler, Milstein}},
+		{"merton", Merton{S0: 100, Drift: mu, Vol: 0.2, Lambda: 0.5, JumpMean: -0.1, JumpVol: 0.15}, []Scheme{Euler, Milstein, Exact}},
+		{"local-vol", LocalVol{S0: 100, Drift: mu, Vol: func(_, s float64) float64 { return 0.2 * math.Sqrt(100/s) }}, []Scheme{Euler, Milstein}},
+	}
+	for _, tt := range tests {
+		for _, scheme := range tt.schemes {
+			t.Run(tt.name+"/"+string(scheme), func(t *testing.T) {
+				mean, variance := moments(tt.p, scheme, T, 50, 40000, 0)
+				stdErr := math.Sqrt(variance / 40000)
+				assert.InDelta(t, forward, mean, 4*stdErr+0.05)
+			})
+		}
+	}
+}
+
+func TestHestonVarianceMean(t *testing.T) {
+	p := Heston{S0: 100, V0: 0.02, Drift: 0.03, Kappa: 2, Theta: 0.05, Xi: 0.3, Rho: -0.5}
+	for _, scheme := range []Scheme{Euler, Milstein} {
+		mean, _ := moments(p, scheme, 1, 100, 20000, 1)
+		want := p.Theta + (p.V0-p.Theta)*math.Exp(-p.Kappa)
+		assert.InDelta(t, want, mean, 0.002, string(scheme))
+	}
+}
+
+func TestShortRateMoments(t *testing.T) {
+	const T = 2.0
+	kappa, theta, sigma, r0 := 0.8, 0.04, 0.01, 0.02
+	decay := math.Exp(-kappa * T)
+	wantMean := theta + (r0-theta)*decay
+	wantVar := sigma * sigma * (1 - decay*decay) / (2 * kappa)
+
+	vasicek := Vasicek{R0: r0, Kappa: kappa, Theta: theta, Sigma: sigma}
+	// The exact scheme is exact in one step
+	mean, variance := moments(vasicek, Exact, T, 1, 50000, 0)
+	assert.InDelta(t, wantMean, mean, 1e-4)
+	assert.InDelta(t, wantVar, variance, 0.03*wantVar)
+	mean, _ = moments(vasicek, Euler, T, 100, 20000, 0)
+	assert.InDelta(t, wantMean, mean, 2e-4)
+
+	hw := HullWhite{R0: r0, A: kappa, Sigma: sigma, Theta: func(float64) float64 { return kappa * theta }}
+	hwMean, hwVar := moments(hw, Exact, T, 1, 50000, 0)
+	vMean, vVar := moments(vasicek, Exact, T, 1, 50000, 0)
+	assert.InDelta(t, vMean, hwMean, 1e-15)
+	assert.InDelta(t, vVar, hwVar, 1e-15)
+
+	cir := CIR{R0: r0, Kappa: kappa, Theta: theta, Sigma: 0.1}
+	for _, scheme := range []Scheme{Euler, Milstein} {
+		mean, _ := moments(cir, scheme, T, 100, 20000, 0)
+		assert.InDelta(t, wantMean, mean, 5e-4, string(scheme))
+	}
+}
+
+func TestLocalVolWithFlatVolMatchesGBM(t *testing.T) {
+	gbm := GBM{S0: 100, Drift: 0.05, Vol: 0.2}
+	lv := LocalVol{S0: 100, Drift: 0.05, Vol: func(float64, float64) float64 { return 0.2 }}
+	times := []float64{0.25, 0.5, 1}
+	z := []float64{0.3, -1.2, 0.8}
+	want, got := make([]float64, 3), make([]float64, 3)
+	Observe(gbm, Exact, times, z, make([]float64, 1), want)
+	Observe(lv, Euler, times, z, make([]float64, 1), got)
+	assert.InDeltaSlice(t, want, got, 1e-12)
+}
+
+func TestLocalVolMilsteinAbsorbsAtZero(t *testing.T) {
+	// A steep negative skew turns the Milstein correction negative enough
+	// to floor the spot at zero, where it must stay without going NaN
+	lv := LocalVol{S0: 100, Drift: 0.05, Vol: func(_, s float64) float64 { return math.Pow(100/s, 3) }}
+	x := []float64{100}
+	lv.Step(Milstein, 0, 0.25, x, []float64{-2})
+	assert.Equal(t, 0.0, x[0])
+	for i := 0; i < 3; i++ {
+		lv.Step(Milstein, 0.25, 0.25, x, []float64{0.5})
+		lv.Step(Euler, 0.25, 0.25, x, []float64{0.5})
+	}
+	assert.Equal(t, 0.0, x[0])
+
+	times := []float64{0.5, 1}
+	out := make([]float64, 2)
+	Observe(LocalVol{S0: 0, Drift: 0.05, Vol: lv.Vol}, Milstein, times, []float64{1, -1}, make([]float64, 1), out)
+	assert.Equal(t, []float64{0, 0}, out)
+}
+
+func TestResolve(t *testing.T) {
+	scheme, err := Resolve(GBM{}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, Exact, scheme)
+
+	scheme, err = Resolve(Heston{}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, Euler, scheme)
+
+	_, err = Resolve(CIR{}, Exact)
+	assert.True(t, errors.Is(err, ErrUnsupportedScheme))
+}
+
+func TestPoissonInv(t *testing.T) {
+	assert.Equal(t, 0, poissonInv(2, 0.1))
+	assert.Equal(t, 1, poissonInv(2, 0.3))
+	assert.Equal(t, 2, poissonInv(2, 0.5))
+	assert.Equal(t, 0, poissonInv(0, 0.999))
+}
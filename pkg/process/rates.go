@@ -0,0 +1,112 @@
+package process
+
+import "math"
+
+// CIR is the Cox-Ingersoll-Ross short-rate model
+//
+//	dr = Kappa(Theta - r) dt + Sigma sqrt(r) dW.
+//
+// Both schemes use full truncation: the rate may dip below zero between
+// steps but only its positive part drives the drift and diffusion.
+type CIR struct {
+	R0    float64
+	Kappa float64
+	Theta float64
+	Sigma float64
+}
+
+func (p CIR) Initial() []float64 {
+	return []float64{p.R0}
+}
+
+func (p CIR) Factors() int {
+	return 1
+}
+
+func (p CIR) Supports(scheme Scheme) bool {
+	return scheme == Euler || scheme == Milstein
+}
+
+func (p CIR) Step(scheme Scheme, _, dt float64, x, z []float64) {
+	r := math.Max(x[0], 0)
+	dW := math.Sqrt(dt) * z[0]
+	next := x[0] + p.Kappa*(p.Theta-r)*dt + p.Sigma*math.Sqrt(r)*dW
+	if scheme == Milstein {
+		next += 0.25 * p.Sigma * p.Sigma * (dW*dW - dt)
+	}
+	x[0] = next
+}
+
+// Vasicek is the Ornstein-Uhlenbeck short-rate model
+//
+//	dr = Kappa(Theta - r) dt + Sigma dW.
+//
+// The noise is additive, so Milstein coincides with Euler.
+type Vasicek struct {
+	R0    float64
+	Kappa float64
+	Theta float64
+	Sigma float64
+}
+
+func (p Vasicek) Initial() []float64 {
+	return []float64{p.R0}
+}
+
+func (p Vasicek) Factors() int {
+	return 1
+}
+
+func (p Vasicek) Supports(scheme Scheme) bool {
+	return scheme == Euler || scheme == Milstein || scheme == Exact
+}
+
+func (p Vasicek) Step(scheme Scheme, _, dt float64, x, z []float64) {
+	x[0] = ouStep(scheme, x[0], p.Kappa, p.Kappa*p.Theta, p.Sigma, dt, z[0])
+}
+
+// HullWhite is the one-factor Hull-White model
+//
+//	dr = (Theta(t) - A r) dt + Sigma dW,
+//
+// where Theta is usually fitted to the initial term structure. The exact
+// scheme integrates the noise exactly and holds Theta at its mid-step value.
+type HullWhite struct {
+	R0    float64
+	A     float64
+	Sigma float64
+	Theta func(t float64) float64
+}
+
+func (p HullWhite) Initial() []float64 {
+	return []float64{p.R0}
+}
+
+func (p HullWhite) Factors() int {
+	return 1
+}
+
+func (p HullWhite) Supports(scheme Scheme) bool {
+	return scheme == Euler || scheme == Milstein || scheme == Exact
+}
+
+func (p HullWhite) Step(scheme Scheme, t, dt float64, x, z []float64) {
+	theta := p.Theta(t)
+	if scheme == Exact {
+		theta = p.Theta(t + 0.5*dt)
+	}
+	x[0] = ouStep(scheme, x[0], p.A, theta, p.Sigma, dt, z[0])
+}
+
+// ouStep advances dr = (level - a r) dt + sigma dW over dt.
+func ouStep(scheme Scheme, r, a, level, sigma, dt, z float64) float64 {
+	if scheme != Exact {
+		return r + (level-a*r)*dt + sigma*math.Sqrt(dt)*z
+	}
+	if a == 0 {
+		return r + level*dt + sigma*math.Sqrt(dt)*z
+	}
+	decay := math.Exp(-a * dt)
+	std := sigma * math.Sqrt((1-decay*decay)/(2*a))
+	return r*decay + level/a*(1-decay) + std*z
+}
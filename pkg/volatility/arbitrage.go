@@ -0,0 +1,141 @@
+package volatility
+
+import (
+	"math"
+	"time"
+)
+
+// ArbitrageKind names the static arbitrage a Violation breaks.
+type ArbitrageKind string
+
+const (
+	// CalendarArbitrage is total variance falling between two expiries at
+	// the same moneyness, which makes a calendar spread worth less than
+	// nothing.
+	CalendarArbitrage ArbitrageKind = "calendar"
+	// ButterflyArbitrage is call prices that are not convex and decreasing
+	// in strike, which makes a butterfly or call spread worth less than
+	// nothing.
+	ButterflyArbitrage ArbitrageKind = "butterfly"
+)
+
+// Violation is a static arbitrage found on the surface. Amount is its size:
+// the fall in total variance for calendar arbitrage, and the fall in the
+// undiscounted call price per unit forward for butterfly arbitrage.
+type Violation struct {
+	Kind   ArbitrageKind
+	Expiry time.Time
+	Strike float64
+	Amount float64
+}
+
+// arbitrageTolerance absorbs rounding in the checks.
+const arbitrageTolerance = 1e-12
+
+// checkPoints is the number of strikes tested between neighbouring quotes,
+// so that arbitrage introduced by the interpolation is found too.
+const checkPoints = 4
+
+// CheckArbitrage tests the quoted part of the surface for static arbitrage
+// and returns the violations found, or none for a clean surface. Total
+// variance must not fall between consecutive expiries where both are
+// quoted, and each slice's call prices must be decreasing and convex in
+// strike, with slopes inside [-1, 0].
+func (s *Surface) CheckArbitrage() []Violation {
+	var out []Violation
+	for i, sl := range s.slices {
+		out = append(out, s.butterfly(sl)...)
+		if i > 0 {
+			out = append(out, s.calendar(s.slices[i-1], sl)...)
+		}
+	}
+	return out
+}
+
+// butterfly tests the call prices of sl, per unit forward, for butterfly
+// arbitrage.
+func (s *Surface) butterfly(sl slice) []Violation {
+	ks := refine(sl.k)
+	strikes := make([]float64, len(ks))
+	calls := make([]float64, len(ks))
+	for i, k := range ks {
+		strikes[i] = math.Exp(k)
+		calls[i] = normalisedCall(k, interpolate(sl.k, sl.w, sl.m, k, s.Interpolation))
+	}
+
+	var out []Violation
+	report := func(i int, amount float64) {
+		if amount > arbitrageTolerance {
+			out = append(out, Violation{
+				Kind:   ButterflyArbitrage,
+				Expiry: sl.expiry,
+				Strike: s.Forward(sl.t) * strikes[i],
+				Amount: amount,
+			})
+		}
+	}
+	prev := math.NaN()
+	for i := 1; i < len(ks); i++ {
+		slope := (calls[i] - calls[i-1]) / (strikes[i] - strikes[i-1])
+		// A call spread is worth between nothing and its width
+		report(i, math.Max(slope, -1-slope)*(strikes[i]-strikes[i-1]))
+		if i > 1 {
+			// and a butterfly at least nothing
+			report(i-1, (prev-slope)*(strikes[i]-strikes[i-1]))
+		}
+		prev = slope
+	}
+	return out
+}
+
+// calendar tests the total variance of consecutive slices for calendar
+// arbitrage where both are quoted.
+func (s *Surface) calendar(near, far slice) []Violation {
+	lo := math.Max(near.k[0], far.k[0])
+	hi := math.Min(near.k[len(near.k)-1], far.k[len(far.k)-1])
+
+	var out []Violation
+	for _, k := range append(refine(near.k), refine(far.k)...) {
+		if k < lo || k > hi {
+			continue
+		}
+		w0 := interpolate(near.k, near.w, near.m, k, s.Interpolation)
+		w1 := interpolate(far.k, far.w, far.m, k, s.Interpolation)
+		if w0-w1 > arbitrageTolerance {
+			out = append(out, Violation{
+				Kind:   CalendarArbitrage,
+				Expiry: far.expiry,
+				Strike: s.Forward(far.t) * math.Exp(k),
+				Amount: w0 - w1,
+			})
+		}
+	}
+	return out
+}
+
+// refine returns the sorted points x with checkPoints-1 more between each
+// neighbouring pair.
+func refine(x []float64) []float64 {
+	out := []float64{x[0]}
+	for i := 1; i < len(x); i++ {
+		for j := 1; j <= checkPoints; j++ {
+			out = append(out, x[i-1]+(x[i]-x[i-1])*float64(j)/checkPoints)
+		}
+	}
+	return out
+}
+
+// normalisedCall returns the undiscounted Black call price per unit forward
+// at log-moneyness k and total variance w.
+func normalisedCall(k, w float64) float64 {
+	if w <= 0 {
+		return math.Max(1-math.Exp(k), 0)
+	}
+	sd := math.Sqrt(w)
+	d1 := -k/sd + sd/2
+	return normCdf(d1) - math.Exp(k)*normCdf(d1-sd)
+}
+
+func normCdf(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
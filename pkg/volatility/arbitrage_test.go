@@ -0,0 +1,45 @@
+package volatility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckArbitrage_Calendar(t *testing.T) {
+	// A one-year volatility far below the six-month one cuts total variance
+	quotes := smileQuotes([]float64{0.5}, []float64{80, 90, 100, 110, 120})
+	for _, K := range []float64{80, 90, 100, 110, 120} {
+		quotes = append(quotes, Quote{Expiry: yearsFrom(asOf, 1), Strike: K, Vol: 0.5 * smile(0.5, K)})
+	}
+	s, err := NewSurface(asOf, 100, 0, 0, quotes)
+	assert.NoError(t, err)
+
+	violations := s.CheckArbitrage()
+	if assert.NotEmpty(t, violations) {
+		for _, v := range violations {
+			assert.Equal(t, CalendarArbitrage, v.Kind)
+			assert.Equal(t, yearsFrom(asOf, 1), v.Expiry)
+			assert.Greater(t, v.Amount, 0.0)
+		}
+	}
+}
+
+func TestCheckArbitrage_Butterfly(t *testing.T) {
+	// A volatility spike at one strike makes the call prices concave there
+	quotes := []Quote{
+		{Expiry: yearsFrom(asOf, 1), Strike: 90, Vol: 0.2},
+		{Expiry: yearsFrom(asOf, 1), Strike: 100, Vol: 0.6},
+		{Expiry: yearsFrom(asOf, 1), Strike: 110, Vol: 0.2},
+	}
+	s, err := NewSurface(asOf, 100, 0, 0, quotes)
+	assert.NoError(t, err)
+
+	violations := s.CheckArbitrage()
+	if assert.NotEmpty(t, violations) {
+		for _, v := range violations {
+			assert.Equal(t, ButterflyArbitrage, v.Kind)
+			assert.InDelta(t, 100, v.Strike, 10+1e-9, "around the spike")
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package volatility
+
+import "sort"
+
+// Interpolation selects how a slice interpolates total variance between
+// quoted log-moneyness points.
+type Interpolation string
+
+const (
+	// Linear interpolates total variance linearly in log-moneyness.
+	Linear Interpolation = "linear"
+	// CubicSpline interpolates total variance with a natural cubic spline
+	// in log-moneyness, which keeps the smile's curvature smooth.
+	CubicSpline Interpolation = "cubic-spline"
+)
+
+// Extrapolation selects how the surface is extended beyond its quotes.
+type Extrapolation string
+
+const (
+	// FlatExtrapolation holds the implied volatility of the outermost quote.
+	FlatExtrapolation Extrapolation = "flat"
+	// LinearExtrapolation continues the total variance along its slope at
+	// the outermost quotes. In strike the slope is kept within Lee's moment
+	// bound of 2 and never turns variance down away from the quotes; in time
+	// the forward variance of the last two expiries carries on.
+	LinearExtrapolation Extrapolation = "linear"
+	// NoExtrapolation refuses queries outside the quotes with ErrOutOfRange.
+	NoExtrapolation Extrapolation = "none"
+)
+
+// spline returns the second derivatives at x of the natural cubic spline
+// through (x, y).
+func spline(x, y []float64) []float64 {
+	n := len(x)
+	m := make([]float64, n)
+	if n < 3 {
+		return m
+	}
+	// Tridiagonal system for the interior second derivatives
+	c := make([]float64, n)
+	d := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		h0, h1 := x[i]-x[i-1], x[i+1]-x[i]
+		a, b := h0/6, (h0+h1)/3
+		rhs := (y[i+1]-y[i])/h1 - (y[i]-y[i-1])/h0
+		if i > 1 {
+			b -= a * c[i-1]
+			rhs -= a * d[i-1]
+		}
+		c[i] = h1 / 6 / b
+		d[i] = rhs / b
+	}
+	for i := n - 2; i >= 1; i-- {
+		m[i] = d[i] - c[i]*m[i+1]
+	}
+	return m
+}
+
+// interpolate evaluates the linear or natural cubic spline interpolant of
+// (x, y), with spline second derivatives m, at x0 inside [x[0], x[n-1]].
+func interpolate(x, y, m []float64, x0 float64, method Interpolation) float64 {
+	n := len(x)
+	if n == 1 {
+		return y[0]
+	}
+	i := sort.SearchFloat64s(x, x0)
+	switch {
+	case i == 0:
+		i = 1
+	case i == n:
+		i = n - 1
+	}
+	h := x[i] - x[i-1]
+	a := (x[i] - x0) / h
+	b := 1 - a
+	v := a*y[i-1] + b*y[i]
+	if method == CubicSpline {
+		v += ((a*a*a-a)*m[i-1] + (b*b*b-b)*m[i]) * h * h / 6
+	}
+	return v
+}
+
+// edgeSlopes returns the slopes of the interpolant of (x, y) at its two
+// ends.
+func edgeSlopes(x, y, m []float64, method Interpolation) (float64, float64) {
+	n := len(x)
+	if n == 1 {
+		return 0, 0
+	}
+	h0, h1 := x[1]-x[0], x[n-1]-x[n-2]
+	left := (y[1] - y[0]) / h0
+	right := (y[n-1] - y[n-2]) / h1
+	if method == CubicSpline {
+		left -= h0 * (2*m[0] + m[1]) / 6
+		right += h1 * (m[n-2] + 2*m[n-1]) / 6
+	}
+	return left, right
+}
@@ -0,0 +1,270 @@
+// Package volatility builds implied volatility surfaces from quoted option
+// prices or volatilities. A Surface implements market.VolSurface, so once
+// recorded in a snapshot every pricer looks up the volatility of each
+// option at its own strike and expiry.
+package volatility
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/pricing"
+)
+
+var (
+	// ErrInvalidQuote is returned for quotes the surface cannot be built on.
+	ErrInvalidQuote = errors.New("volatility: invalid quote")
+	// ErrOutOfRange is returned for queries outside the quotes when
+	// extrapolation is switched off.
+	ErrOutOfRange = errors.New("volatility: outside the quoted surface")
+)
+
+// Quote is a market quote of a European option on the surface's
+// underlying. Vol is its Black-Scholes implied volatility; when zero, the
+// volatility is implied from Price, the option's premium.
+type Quote struct {
+	Expiry     time.Time
+	Strike     float64
+	Vol        float64
+	Price      float64
+	OptionType instrument.OptionType
+}
+
+// Surface is an implied volatility surface. Each quoted expiry is a slice
+// of total variance w = sigma^2 T against log-moneyness k = ln(K/F), with
+// F the forward S e^{(r-q)T}; slices are interpolated in k and total
+// variance linearly in time at constant k. Before the first expiry the
+// first slice's volatility is held.
+//
+// Interpolation, StrikeExtrapolation and TimeExtrapolation may be changed
+// before the surface is used; they default to Linear and
+// FlatExtrapolation.
+type Surface struct {
+	Interpolation       Interpolation
+	StrikeExtrapolation Extrapolation
+	TimeExtrapolation   Extrapolation
+
+	asOf          time.Time
+	spot          float64
+	rate          float64
+	dividendYield float64
+	slices        []slice
+}
+
+var _ market.VolSurface = (*Surface)(nil)
+
+// slice holds the quotes of one expiry, sorted by log-moneyness.
+type slice struct {
+	expiry time.Time
+	t      float64
+	k, w   []float64
+	m      []float64 // spline second derivatives of w
+}
+
+// NewSurface builds the surface of an underlying at spot, with risk-free
+// rate r and dividend yield q, from quotes valued at asOf. Quotes without
+// a volatility are inverted with pricing.ImpliedVolatility.
+func NewSurface(asOf time.Time, spot, r, q float64, quotes []Quote) (*Surface, error) {
+	switch {
+	case !(spot > 0) || math.IsInf(spot, 0):
+		return nil, fmt.Errorf("%w: spot %g", ErrInvalidQuote, spot)
+	case math.IsNaN(r) || math.IsInf(r, 0):
+		return nil, fmt.Errorf("%w: rate %g", ErrInvalidQuote, r)
+	case math.IsNaN(q) || math.IsInf(q, 0):
+		return nil, fmt.Errorf("%w: dividend yield %g", ErrInvalidQuote, q)
+	case len(quotes) == 0:
+		return nil, fmt.Errorf("%w: no quotes", ErrInvalidQuote)
+	}
+	s := &Surface{asOf: asOf, spot: spot, rate: r, dividendYield: q}
+
+	byExpiry := make(map[int64]*slice)
+	for _, quote := range quotes {
+		t := years(asOf, quote.Expiry)
+		if !(t > 0) || math.IsInf(t, 0) {
+			return nil, fmt.Errorf("%w: expiry %s is not after %s", ErrInvalidQuote, quote.Expiry.Format(time.RFC3339), asOf.Format(time.RFC3339))
+		}
+		if !(quote.Strike > 0) || math.IsInf(quote.Strike, 0) {
+			return nil, fmt.Errorf("%w: strike %g", ErrInvalidQuote, quote.Strike)
+		}
+		vol, err := s.impliedVol(quote, t)
+		if err != nil {
+			return nil, err
+		}
+
+		sl, ok := byExpiry[quote.Expiry.UnixNano()]
+		if !ok {
+			sl = &slice{expiry: quote.Expiry, t: t}
+			byExpiry[quote.Expiry.UnixNano()] = sl
+		}
+		sl.k = append(sl.k, math.Log(quote.Strike/s.Forward(t)))
+		sl.w = append(sl.w, vol*vol*t)
+	}
+
+	for _, sl := range byExpiry {
+		sort.Sort(byMoneyness{sl})
+		for i := 1; i < len(sl.k); i++ {
+			if sl.k[i] == sl.k[i-1] {
+				return nil, fmt.Errorf("%w: strike %g quoted twice for %s", ErrInvalidQuote,
+					s.Forward(sl.t)*math.Exp(sl.k[i]), sl.expiry.Format(time.RFC3339))
+			}
+		}
+		sl.m = spline(sl.k, sl.w)
+		s.slices = append(s.slices, *sl)
+	}
+	sort.Slice(s.slices, func(i, j int) bool { return s.slices[i].t < s.slices[j].t })
+	return s, nil
+}
+
+// impliedVol returns the volatility of quote, expiring in t years.
+func (s *Surface) impliedVol(quote Quote, t float64) (float64, error) {
+	if quote.Vol != 0 {
+		if !(quote.Vol > 0) || math.IsInf(quote.Vol, 0) {
+			return 0, fmt.Errorf("%w: volatility %g at strike %g", ErrInvalidQuote, quote.Vol, quote.Strike)
+		}
+		return quote.Vol, nil
+	}
+	in := pricing.Inputs{
+		Spot:          s.spot,
+		Strike:        quote.Strike,
+		Expiry:        t,
+		RiskFreeRate:  s.rate,
+		DividendYield: s.dividendYield,
+	}
+	vol, err := pricing.ImpliedVolatility(quote.Price, in, quote.OptionType)
+	if err != nil {
+		return 0, fmt.Errorf("%w: price %g at strike %g: %w", ErrInvalidQuote, quote.Price, quote.Strike, err)
+	}
+	return vol, nil
+}
+
+// byMoneyness sorts the quotes of a slice by log-moneyness.
+type byMoneyness struct{ *slice }
+
+func (b byMoneyness) Len() int           { return len(b.k) }
+func (b byMoneyness) Less(i, j int) bool { return b.k[i] < b.k[j] }
+func (b byMoneyness) Swap(i, j int) {
+	b.k[i], b.k[j] = b.k[j], b.k[i]
+	b.w[i], b.w[j] = b.w[j], b.w[i]
+}
+
+// years returns the ACT/365 year fraction from one time to another, as the
+// pricers measure expiries.
+func years(from, to time.Time) float64 {
+	return to.Sub(from).Hours() / (24 * 365)
+}
+
+// AsOf returns the valuation time the surface's expiries are measured from.
+func (s *Surface) AsOf() time.Time {
+	return s.asOf
+}
+
+// Expiries returns the quoted expiries in increasing order.
+func (s *Surface) Expiries() []time.Time {
+	out := make([]time.Time, len(s.slices))
+	for i, sl := range s.slices {
+		out[i] = sl.expiry
+	}
+	return out
+}
+
+// Forward returns the forward of the underlying t years out.
+func (s *Surface) Forward(t float64) float64 {
+	return s.spot * math.Exp((s.rate-s.dividendYield)*t)
+}
+
+// Volatility returns the implied volatility at expiry, in years from AsOf,
+// and strike.
+func (s *Surface) Volatility(expiry, strike float64) (float64, error) {
+	if !(expiry > 0) || math.IsInf(expiry, 0) {
+		return 0, fmt.Errorf("volatility: expiry %g years", expiry)
+	}
+	if !(strike > 0) || math.IsInf(strike, 0) {
+		return 0, fmt.Errorf("volatility: strike %g", strike)
+	}
+	w, err := s.TotalVariance(expiry, math.Log(strike/s.Forward(expiry)))
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(w / expiry), nil
+}
+
+// TotalVariance returns the total implied variance sigma^2 t at t years
+// and log-moneyness k.
+func (s *Surface) TotalVariance(t, k float64) (float64, error) {
+	n := len(s.slices)
+	i := sort.Search(n, func(i int) bool { return s.slices[i].t >= t })
+	switch {
+	case i < n && s.slices[i].t == t:
+		return s.sliceVariance(s.slices[i], k)
+	case i == 0:
+		// Hold the first slice's volatility back to the valuation time
+		w, err := s.sliceVariance(s.slices[0], k)
+		return w * t / s.slices[0].t, err
+	case i < n:
+		lo, hi := s.slices[i-1], s.slices[i]
+		w0, err := s.sliceVariance(lo, k)
+		if err != nil {
+			return 0, err
+		}
+		w1, err := s.sliceVariance(hi, k)
+		if err != nil {
+			return 0, err
+		}
+		a := (t - lo.t) / (hi.t - lo.t)
+		return (1-a)*w0 + a*w1, nil
+	}
+
+	last := s.slices[n-1]
+	w, err := s.sliceVariance(last, k)
+	if err != nil {
+		return 0, err
+	}
+	switch s.TimeExtrapolation {
+	case NoExtrapolation:
+		return 0, fmt.Errorf("%w: %g years is after the last expiry", ErrOutOfRange, t)
+	case LinearExtrapolation:
+		if n > 1 {
+			prev, err := s.sliceVariance(s.slices[n-2], k)
+			if err != nil {
+				return 0, err
+			}
+			// Carry on the forward variance, which is never negative
+			forward := math.Max((w-prev)/(last.t-s.slices[n-2].t), 0)
+			return w + forward*(t-last.t), nil
+		}
+	}
+	return w * t / last.t, nil
+}
+
+// sliceVariance returns the total variance of sl at log-moneyness k.
+func (s *Surface) sliceVariance(sl slice, k float64) (float64, error) {
+	n := len(sl.k)
+	lo, hi := sl.k[0], sl.k[n-1]
+	if k >= lo && k <= hi {
+		return interpolate(sl.k, sl.w, sl.m, k, s.Interpolation), nil
+	}
+
+	switch s.StrikeExtrapolation {
+	case NoExtrapolation:
+		return 0, fmt.Errorf("%w: log-moneyness %g outside [%g, %g] for %s", ErrOutOfRange, k, lo, hi, sl.expiry.Format(time.RFC3339))
+	case LinearExtrapolation:
+		left, right := edgeSlopes(sl.k, sl.w, sl.m, s.Interpolation)
+		if k < lo {
+			return sl.w[0] + math.Max(math.Min(left, 0), -leeBound)*(k-lo), nil
+		}
+		return sl.w[n-1] + math.Min(math.Max(right, 0), leeBound)*(k-hi), nil
+	}
+	if k < lo {
+		return sl.w[0], nil
+	}
+	return sl.w[n-1], nil
+}
+
+// leeBound is Lee's bound on the slope of total variance in log-moneyness
+// far from the money, beyond which the smile admits arbitrage.
+const leeBound = 2
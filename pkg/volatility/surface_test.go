@@ -0,0 +1,215 @@
+package volatility
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antigravity/go-finance-sdk/pkg/instrument"
+	"github.com/antigravity/go-finance-sdk/pkg/market"
+	"github.com/antigravity/go-finance-sdk/pkg/pricing"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var asOf = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+// yearsFrom returns the instant that is the given ACT/365 year fraction after t.
+func yearsFrom(t time.Time, years float64) time.Time {
+	return t.Add(time.Duration(years * 365 * 24 * float64(time.Hour)))
+}
+
+// smile is a skewed smile whose level falls with expiry more slowly than
+// the total variance grows, so it is free of arbitrage.
+func smile(t, strike float64) float64 {
+	k := math.Log(strike / 100)
+	return 0.2 + 0.02/math.Sqrt(t) - 0.1*k + 0.15*k*k
+}
+
+func smileQuotes(expiries, strikes []float64) []Quote {
+	var quotes []Quote
+	for _, T := range expiries {
+		for _, K := range strikes {
+			quotes = append(quotes, Quote{Expiry: yearsFrom(asOf, T), Strike: K, Vol: smile(T, K)})
+		}
+	}
+	return quotes
+}
+
+func TestSurface_Quotes(t *testing.T) {
+	expiries := []float64{0.25, 0.5, 1, 2}
+	strikes := []float64{70, 80, 90, 100, 110, 120, 130}
+	for _, method := range []Interpolation{Linear, CubicSpline} {
+		s, err := NewSurface(asOf, 100, 0, 0, smileQuotes(expiries, strikes))
+		assert.NoError(t, err)
+		s.Interpolation = method
+		assert.Len(t, s.Expiries(), len(expiries))
+		for _, T := range expiries {
+			for _, K := range strikes {
+				vol, err := s.Volatility(T, K)
+				assert.NoError(t, err)
+				assert.InDelta(t, smile(T, K), vol, 1e-9, "%s at %g, %g", method, T, K)
+			}
+		}
+		assert.Empty(t, s.CheckArbitrage(), method)
+	}
+}
+
+func TestSurface_Interpolation(t *testing.T) {
+	strikes := []float64{60, 70, 80, 90, 100, 110, 120, 130, 140}
+	s, err := NewSurface(asOf, 100, 0, 0, smileQuotes([]float64{1}, strikes))
+	assert.NoError(t, err)
+
+	// Between strikes the spline follows the smile closer than the line
+	linear, _ := s.Volatility(1, 95)
+	s.Interpolation = CubicSpline
+	cubic, _ := s.Volatility(1, 95)
+	want := smile(1, 95)
+	assert.Less(t, math.Abs(cubic-want), math.Abs(linear-want))
+	assert.InDelta(t, want, cubic, 1e-4)
+
+	// Total variance is linear in time at constant moneyness
+	s, err = NewSurface(asOf, 100, 0.05, 0.01, smileQuotes([]float64{0.5, 1}, strikes))
+	assert.NoError(t, err)
+	w0, _ := s.TotalVariance(0.5, 0.1)
+	w1, _ := s.TotalVariance(1, 0.1)
+	w, err := s.TotalVariance(0.75, 0.1)
+	assert.NoError(t, err)
+	assert.InDelta(t, (w0+w1)/2, w, 1e-12)
+
+	vol, err := s.Volatility(0.75, s.Forward(0.75)*math.Exp(0.1))
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Sqrt(w/0.75), vol, 1e-12)
+
+	// and the first expiry's volatility is held before it
+	short, err := s.Volatility(0.1, s.Forward(0.1))
+	assert.NoError(t, err)
+	atm, _ := s.Volatility(0.5, s.Forward(0.5))
+	assert.InDelta(t, atm, short, 1e-12)
+}
+
+func TestSurface_Extrapolation(t *testing.T) {
+	s, err := NewSurface(asOf, 100, 0, 0, smileQuotes([]float64{0.5, 1}, []float64{80, 90, 100, 110, 120}))
+	assert.NoError(t, err)
+
+	// Flat in strike holds the edge volatility
+	edge, _ := s.Volatility(1, 80)
+	vol, err := s.Volatility(1, 50)
+	assert.NoError(t, err)
+	assert.InDelta(t, edge, vol, 1e-12)
+
+	// Linear carries the skew on, within Lee's bound
+	s.StrikeExtrapolation = LinearExtrapolation
+	vol, err = s.Volatility(1, 50)
+	assert.NoError(t, err)
+	assert.Greater(t, vol, edge)
+	for _, K := range []float64{1e-6, 1e6} {
+		w, err := s.TotalVariance(1, math.Log(K/100))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, w, 2*math.Abs(math.Log(K/100))+1)
+	}
+
+	s.StrikeExtrapolation = NoExtrapolation
+	_, err = s.Volatility(1, 50)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, err = s.Volatility(1, 100)
+	assert.NoError(t, err)
+
+	// Flat in time holds the last expiry's volatility
+	last, _ := s.Volatility(1, 100)
+	vol, err = s.Volatility(3, 100)
+	assert.NoError(t, err)
+	assert.InDelta(t, last, vol, 1e-12)
+
+	// Linear carries the forward variance on
+	s.TimeExtrapolation = LinearExtrapolation
+	w0, _ := s.TotalVariance(0.5, 0)
+	w1, _ := s.TotalVariance(1, 0)
+	w, err := s.TotalVariance(3, 0)
+	assert.NoError(t, err)
+	assert.InDelta(t, w1+(w1-w0)/0.5*2, w, 1e-12)
+
+	s.TimeExtrapolation = NoExtrapolation
+	_, err = s.Volatility(3, 100)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func TestSurface_PriceQuotes(t *testing.T) {
+	mkt := market.NewSnapshot(asOf).SetSpot("TEST", 100).SetDividendYield("TEST", 0.02)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	expiry := yearsFrom(asOf, 0.5)
+
+	var quotes []Quote
+	for _, K := range []float64{80, 100, 120} {
+		optType := instrument.Put
+		if K >= 100 {
+			optType = instrument.Call
+		}
+		opt := instrument.NewEuropeanOption("O", underlying, decimal.NewFromFloat(K), expiry, optType)
+		res, err := pricing.NewBlackScholesPricer(mkt, 0.03, smile(0.5, K)).Price(context.Background(), opt)
+		assert.NoError(t, err)
+		quotes = append(quotes, Quote{Expiry: expiry, Strike: K, Price: res.Value(), OptionType: optType})
+	}
+
+	s, err := NewSurface(asOf, 100, 0.03, 0.02, quotes)
+	assert.NoError(t, err)
+	for _, K := range []float64{80, 100, 120} {
+		vol, err := s.Volatility(0.5, K)
+		assert.NoError(t, err)
+		assert.InDelta(t, smile(0.5, K), vol, 1e-4, "strike %g", K)
+	}
+}
+
+func TestNewSurface_Invalid(t *testing.T) {
+	expiry := yearsFrom(asOf, 1)
+	for name, quotes := range map[string][]Quote{
+		"none":             nil,
+		"expired":          {{Expiry: asOf, Strike: 100, Vol: 0.2}},
+		"negative strike":  {{Expiry: expiry, Strike: -100, Vol: 0.2}},
+		"negative vol":     {{Expiry: expiry, Strike: 100, Vol: -0.2}},
+		"price below zero": {{Expiry: expiry, Strike: 100, Price: -1, OptionType: instrument.Call}},
+		"duplicate strike": {{Expiry: expiry, Strike: 100, Vol: 0.2}, {Expiry: expiry, Strike: 100, Vol: 0.25}},
+	} {
+		_, err := NewSurface(asOf, 100, 0.05, 0, quotes)
+		assert.ErrorIs(t, err, ErrInvalidQuote, name)
+	}
+	_, err := NewSurface(asOf, 0, 0.05, 0, []Quote{{Expiry: expiry, Strike: 100, Vol: 0.2}})
+	assert.ErrorIs(t, err, ErrInvalidQuote)
+}
+
+func TestSurface_Pricing(t *testing.T) {
+	s, err := NewSurface(asOf, 100, 0.03, 0, smileQuotes([]float64{0.5, 1}, []float64{70, 80, 90, 100, 110, 120, 130}))
+	assert.NoError(t, err)
+	s.Interpolation = CubicSpline
+	mkt := market.NewSnapshot(asOf).SetSpot("TEST", 100).SetRiskFreeRate(0.03).
+		SetVolatility("TEST", 0.5).SetVolSurface("TEST", s)
+	underlying := instrument.NewEquity("TEST", "USD", "TEST")
+	expiry := yearsFrom(asOf, 0.75)
+
+	// Each option is priced at the surface's volatility for its own strike,
+	// ahead of the flat one quoted
+	bs := pricing.NewBlackScholesPricer(mkt, 0.03, 0.2)
+	mc := pricing.NewMonteCarloPricer(mkt, 50000, 0.03, 0.2)
+	mc.Seed = 3
+	for _, K := range []float64{85, 100, 115} {
+		opt := instrument.NewEuropeanOption("C", underlying, decimal.NewFromFloat(K), expiry, instrument.Call)
+		want, err := s.Volatility(0.75, K)
+		assert.NoError(t, err)
+
+		res, err := bs.Price(context.Background(), opt)
+		assert.NoError(t, err)
+		assert.InDelta(t, want, res.Inputs.Volatility, 1e-9)
+
+		got, err := mc.Price(context.Background(), opt)
+		assert.NoError(t, err)
+		assert.InDelta(t, res.Value(), got.Value(), 3*got.StdErr)
+	}
+
+	// Queries the surface refuses fail the pricing
+	s.StrikeExtrapolation = NoExtrapolation
+	far := instrument.NewEuropeanOption("C", underlying, decimal.NewFromInt(200), expiry, instrument.Call)
+	_, err = bs.Price(context.Background(), far)
+	assert.ErrorIs(t, err, pricing.ErrMissingMarketData)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}